@@ -0,0 +1,169 @@
+// By Kelvin Yong for Go Challenge 6
+
+// Package client talks to Daedalus over his HTTP API, described in
+// openapi.yaml at the root of the repository. Icarus uses it, and so can
+// any other bot that wants to solve the laybrinths of Daedalus.
+//
+//	c := client.New("http://127.0.0.1:3000")
+//	start, err := c.Awake(ctx)
+//	rep, err := c.Move(ctx, "up")
+//	if errors.Is(err, mazelib.ErrWall) {
+//		// try another way
+//	}
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"bitbucket.org/kelvinyong/gc6/mazelib"
+)
+
+// Client makes the requests of a solver to Daedalus.
+// HTTPClient is http.DefaultClient when nil.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// New returns a client for the Daedalus at baseURL, such as
+// http://127.0.0.1:3000
+func New(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// Error is a reply of Daedalus with "error": true, or telling Icarus he
+// was caught. It unwraps to the error of mazelib with its code, such as
+// mazelib.ErrWall, so callers can use errors.Is. Status is the HTTP
+// status of the reply, or 0 if it didn't come over HTTP.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("daedalus: %s (%s)", e.Message, e.Code)
+	}
+	return "daedalus: " + e.Message
+}
+
+// Unwrap returns the error of mazelib with the code of the reply, or nil
+func (e *Error) Unwrap() error {
+	return mazelib.CodeError(e.Code)
+}
+
+// DecodeError is a response that isn't the JSON Daedalus replies with
+type DecodeError struct {
+	Status int
+	Body   []byte
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("daedalus: can't decode the reply (status %d): %v", e.Status, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Awake wakes Icarus up in a new maze, and returns his first room
+func (c *Client) Awake(ctx context.Context) (mazelib.Reply, error) {
+	return c.reply(ctx, "/awake")
+}
+
+// Move moves Icarus one step. The direction is up, down, left, right,
+// ascend or descend in square rooms, a name in mazelib.DirectionName in
+// hexagonal and polar mazes, or the label of an exit in a graph.
+// When Icarus is caught, the reply has Caught set and the error
+// unwraps to mazelib.ErrCaught.
+func (c *Client) Move(ctx context.Context, direction string) (mazelib.Reply, error) {
+	return c.reply(ctx, "/move/"+url.PathEscape(direction))
+}
+
+// Look looks down the corridors from the room of Icarus
+func (c *Client) Look(ctx context.Context) (mazelib.Reply, error) {
+	return c.reply(ctx, "/look")
+}
+
+// Map returns the map of the current maze, when Daedalus serves it with --map
+func (c *Client) Map(ctx context.Context) (mazelib.Map, error) {
+	var m mazelib.Map
+	status, body, err := c.get(ctx, "/map")
+	if err != nil {
+		return m, err
+	}
+	if status != http.StatusOK {
+		var r mazelib.Reply
+		if err := decode(status, body, &r); err != nil {
+			return m, err
+		}
+		return m, &Error{Status: status, Code: r.Code, Message: r.Message}
+	}
+	return m, decode(status, body, &m)
+}
+
+// Done tells Daedalus that Icarus is done. Daedalus prints the results
+// and exits without answering, so the error of the request is dropped.
+func (c *Client) Done(ctx context.Context) {
+	c.get(ctx, "/done")
+}
+
+// reply makes a request that Daedalus answers with a mazelib.Reply,
+// and returns the reply along with its error, if any
+func (c *Client) reply(ctx context.Context, path string) (mazelib.Reply, error) {
+	var r mazelib.Reply
+	status, body, err := c.get(ctx, path)
+	if err != nil {
+		return r, err
+	}
+	if err := decode(status, body, &r); err != nil {
+		return r, err
+	}
+	return r, ReplyError(r, status)
+}
+
+// ReplyError returns the *Error of a reply that failed or caught Icarus,
+// with its HTTP status, or nil for any other reply
+func ReplyError(r mazelib.Reply, status int) error {
+	if !r.Error && !r.Caught {
+		return nil
+	}
+	code := r.Code
+	if r.Caught {
+		code = mazelib.CodeCaught
+	}
+	return &Error{Status: status, Code: code, Message: r.Message}
+}
+
+func (c *Client) get(ctx context.Context, path string) (int, []byte, error) {
+	req, err := http.NewRequest(http.MethodGet, c.BaseURL+path, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	response, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, nil, err
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	return response.StatusCode, body, err
+}
+
+func decode(status int, body []byte, v interface{}) error {
+	if err := json.Unmarshal(body, v); err != nil {
+		return &DecodeError{Status: status, Body: body, Err: err}
+	}
+	return nil
+}
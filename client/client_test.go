@@ -0,0 +1,123 @@
+// By Kelvin Yong for Go Challenge 6
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"bitbucket.org/kelvinyong/gc6/mazelib"
+)
+
+// daedalus answers each path with a status and a body, as Daedalus would
+func daedalus(t *testing.T) *httptest.Server {
+	replies := map[string]struct {
+		status int
+		body   string
+	}{
+		"/awake":            {http.StatusOK, `{"survey":{"top":true,"left":true},"victory":false,"message":"","error":false}`},
+		"/move/up":          {http.StatusConflict, `{"survey":{},"code":"wall","victory":false,"message":"Can't walk through walls","error":true}`},
+		"/move/left":        {http.StatusOK, `{"survey":{},"caught":true,"code":"caught","victory":false,"message":"Caught by the Minotaur","error":false}`},
+		"/move/north east":  {http.StatusOK, `{"survey":{},"victory":true,"message":"Victory achieved in 3 steps","error":false}`},
+		"/move/sideways":    {http.StatusBadRequest, `{"survey":{},"code":"invalid-direction","victory":false,"message":"invalid direction","error":true}`},
+		"/move/into a void": {http.StatusOK, `{"survey":{},"code":"from-the-future","victory":false,"message":"what?","error":true}`},
+		"/look":             {http.StatusBadGateway, `<html>bad gateway</html>`},
+		"/map":              {http.StatusForbidden, `{"survey":{},"victory":false,"message":"the map is only served with --map","error":true}`},
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rep, ok := replies[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request for %s", r.URL.Path)
+			rep.status = http.StatusNotFound
+		}
+		w.WriteHeader(rep.status)
+		w.Write([]byte(rep.body))
+	}))
+}
+
+func TestReplies(t *testing.T) {
+	srv := daedalus(t)
+	defer srv.Close()
+	c := New(srv.URL + "/")
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		call   func() (mazelib.Reply, error)
+		status int
+		is     error
+	}{
+		{"awake", func() (mazelib.Reply, error) { return c.Awake(ctx) }, 0, nil},
+		{"wall", func() (mazelib.Reply, error) { return c.Move(ctx, "up") }, http.StatusConflict, mazelib.ErrWall},
+		{"caught", func() (mazelib.Reply, error) { return c.Move(ctx, "left") }, http.StatusOK, mazelib.ErrCaught},
+		{"escaped direction", func() (mazelib.Reply, error) { return c.Move(ctx, "north east") }, 0, nil},
+		{"bad direction", func() (mazelib.Reply, error) { return c.Move(ctx, "sideways") }, http.StatusBadRequest, mazelib.ErrInvalidDirection},
+		// a code the client doesn't know is still an error, of no kind
+		{"unknown code", func() (mazelib.Reply, error) { return c.Move(ctx, "into a void") }, http.StatusOK, nil},
+	}
+
+	for _, tt := range tests {
+		_, err := tt.call()
+		var e *Error
+		if tt.status == 0 {
+			if err != nil {
+				t.Errorf("%s: error %v", tt.name, err)
+			}
+			continue
+		}
+		if !errors.As(err, &e) || e.Status != tt.status || errors.Unwrap(e) != tt.is {
+			t.Errorf("%s: error %#v, want status %d wrapping %v", tt.name, err, tt.status, tt.is)
+		}
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	srv := daedalus(t)
+	defer srv.Close()
+	c := New(srv.URL)
+
+	_, err := c.Look(context.Background())
+	var d *DecodeError
+	if !errors.As(err, &d) || d.Status != http.StatusBadGateway || string(d.Body) != "<html>bad gateway</html>" {
+		t.Fatalf("look: error %#v, want a DecodeError with the body", err)
+	}
+	var syntax *json.SyntaxError
+	if !errors.As(err, &syntax) {
+		t.Errorf("look: %v doesn't unwrap to the JSON error", err)
+	}
+
+	// a map that isn't served is an Error, with no code
+	_, err = c.Map(context.Background())
+	var e *Error
+	if !errors.As(err, &e) || e.Status != http.StatusForbidden || e.Code != "" || errors.Unwrap(e) != nil {
+		t.Errorf("map: error %#v, want a forbidden Error", err)
+	}
+}
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		err  *Error
+		want string
+	}{
+		{&Error{Status: http.StatusConflict, Code: mazelib.CodeWall, Message: "Can't walk through walls"}, "daedalus: Can't walk through walls (wall)"},
+		{&Error{Status: http.StatusForbidden, Message: "the map is only served with --map"}, "daedalus: the map is only served with --map"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() is %q, want %q", got, tt.want)
+		}
+	}
+
+	// replies that didn't fail aren't errors, and being caught is
+	if err := ReplyError(mazelib.Reply{Victory: true}, http.StatusOK); err != nil {
+		t.Errorf("victory: error %v", err)
+	}
+	if err := ReplyError(mazelib.Reply{Caught: true}, 0); !errors.Is(err, mazelib.ErrCaught) || !mazelib.Over(err) {
+		t.Errorf("caught: error %v isn't ErrCaught", err)
+	}
+}
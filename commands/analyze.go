@@ -0,0 +1,131 @@
+// Copyright © 2015 Steve Francia <spf@spf13.com>.
+//
+//   Licensed under the Apache License, Version 2.0 (the "License");
+//   you may not use this file except in compliance with the License.
+//   You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+//
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"bitbucket.org/kelvinyong/gc6/mazelib"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Defining the analyze command.
+// This will be called as 'laybrinth analyze'
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Measure the structure of the laybrinths created by daedalus",
+	Long: `Analyze generates laybrinths the same way daedalus does and reports
+  their dead ends, junctions, corridors, solution path, river factor
+  and loops, averaged over the number of times given. Each maze is then
+  solved with FindTreasure, so the steps it takes can be set against
+  the structure of the maze.
+
+  Every generator is analyzed unless one is given with --generator.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		RunAnalyze()
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(analyzeCmd)
+}
+
+// RunAnalyze analyzes as many mazes per generator as the user desires
+// and prints the average of each metric.
+func RunAnalyze() {
+	names, err := generatorNames()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(-1)
+	}
+	if err := loadShape(); err != nil {
+		fmt.Println(err)
+		os.Exit(-1)
+	}
+	if err := checkLayout(); err != nil {
+		fmt.Println(err)
+		os.Exit(-1)
+	}
+	if viper.GetInt("levels") != 1 || viper.GetBool("hex") || viper.GetBool("polar") {
+		fmt.Println("analyze only works with square rooms on a single level")
+		os.Exit(-1)
+	}
+
+	times := viper.GetInt("times")
+	if times < 1 {
+		times = 1
+	}
+
+	solver := mazelib.FindTreasure
+	if viper.GetInt("one-way") > 0 {
+		solver = mazelib.FindTreasureDirected
+	}
+
+	for _, name := range names {
+		var deadEnds, junctions3, junctions4, corridors, longest, solution, solvable, turns, loops int
+		var steps, solved int
+		var meanCorridor, river float64
+		for x := 0; x < times; x++ {
+			m := placeIcarus(generators[name].generate())
+			mt, err := mazelib.Analyze(m)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(-1)
+			}
+			deadEnds += mt.DeadEnds
+			junctions3 += mt.Junctions[3]
+			junctions4 += mt.Junctions[4]
+			corridors += len(mt.Corridors)
+			meanCorridor += mt.MeanCorridor()
+			longest += mt.LongestCorridor()
+			river += mt.RiverFactor
+			loops += mt.Loops
+
+			// mazes without a way to the treasure have no solution to count
+			if mt.SolutionLength < 0 {
+				continue
+			}
+			solution += mt.SolutionLength
+			turns += mt.Turns
+			solvable++
+			if n, found := mazelib.Solve(m, solver, 10*m.Width()*m.Height()); found {
+				steps += n
+				solved++
+			}
+		}
+
+		n := float64(times)
+		fmt.Printf("%s (%d mazes)\n", name, times)
+		fmt.Printf("  dead ends:        %.1f\n", float64(deadEnds)/n)
+		fmt.Printf("  junctions:        %.1f 3-way, %.1f 4-way\n", float64(junctions3)/n, float64(junctions4)/n)
+		fmt.Printf("  corridors:        %.1f, mean length %.1f, longest %.1f\n", float64(corridors)/n, meanCorridor/n, float64(longest)/n)
+		fmt.Printf("  solution length:  %.1f steps, %.1f turns\n", average(solution, solvable), average(turns, solvable))
+		fmt.Printf("  river factor:     %.2f\n", river/n)
+		fmt.Printf("  loops:            %.1f\n", float64(loops)/n)
+		fmt.Printf("  FindTreasure:     %.1f steps, solved %d of %d\n", average(steps, solved), solved, solvable)
+	}
+}
+
+// average returns total / count, or 0 if there is nothing to count
+func average(total, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+	return float64(total) / float64(count)
+}
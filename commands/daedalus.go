@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sort"
 	"time"
 
 	"bitbucket.org/kelvinyong/gc6/mazelib"
@@ -31,24 +32,27 @@ import (
 	"github.com/spf13/viper"
 )
 
-//Maze struct
-type Maze struct {
-	rooms      [][]mazelib.Room
-	start      mazelib.Coordinate
-	end        mazelib.Coordinate
-	icarus     mazelib.Coordinate
-	StepsTaken int
-}
-
 // Tracking the current maze being solved
 
 // WARNING: This approach is not safe for concurrent use
 // This server is only intended to have a single client at a time
 // We would need a different and more complex approach if we wanted
 // concurrent connections than these simple package variables
-var currentMaze *Maze
+var currentMaze labyrinth
+var currentHex *mazelib.HexMaze
+var currentPolar *mazelib.PolarMaze
+var currentGraph *mazelib.GraphMaze
 var scores []int
 
+// currentMinotaur roams the current maze with --minotaur, and caught
+// tells if it has caught Icarus. losses counts the mazes lost to it.
+var currentMinotaur *mazelib.Minotaur
+var caught bool
+var losses int
+
+// exhausted counts the mazes lost for running out of --max-steps
+var exhausted int
+
 // Defining the daedalus command.
 // This will be called as 'laybrinth daedalus'
 var daedalusCmd = &cobra.Command{
@@ -73,6 +77,19 @@ func init() {
 
 // RunServer runs the web server
 func RunServer() {
+	if _, err := generatorNames(); err != nil {
+		fmt.Println(err)
+		os.Exit(-1)
+	}
+	if err := loadShape(); err != nil {
+		fmt.Println(err)
+		os.Exit(-1)
+	}
+	if err := checkLayout(); err != nil {
+		fmt.Println(err)
+		os.Exit(-1)
+	}
+
 	// Adding handling so that even when ctrl+c is pressed we still print
 	// out the results prior to exiting.
 	c := make(chan os.Signal, 1)
@@ -89,9 +106,15 @@ func RunServer() {
 	{
 		v1.GET("/awake", GetStartingPoint)
 		v1.GET("/move/:direction", MoveDirection)
+		v1.GET("/look", Look)
+		v1.GET("/map", Map)
 		v1.GET("/done", End)
 	}
 
+	if port := viper.GetString("grpc-port"); port != "" {
+		go serveGRPC(port)
+	}
+
 	r.Run(":" + viper.GetString("port"))
 }
 
@@ -105,6 +128,24 @@ func End(c *gin.Context) {
 
 // GetStartingPoint initializes a new maze and places Icarus in his awakening location
 func GetStartingPoint(c *gin.Context) {
+	if viper.GetBool("graph") {
+		startGraph(c)
+		return
+	}
+	if viper.GetBool("hex") {
+		startHex(c)
+		return
+	}
+	if viper.GetBool("polar") {
+		startPolar(c)
+		return
+	}
+	c.JSON(wake())
+}
+
+// wake initializes a new maze with square rooms, places Icarus in it and
+// returns the reply to him, with its HTTP status
+func wake() (int, mazelib.Reply) {
 	initializeMaze()
 	startRoom, err := currentMaze.Discover(currentMaze.Icarus())
 	if err != nil {
@@ -112,16 +153,74 @@ func GetStartingPoint(c *gin.Context) {
 		fmt.Println(err)
 		os.Exit(-1)
 	}
-	mazelib.PrintMaze(currentMaze)
+	if m, ok := currentMaze.(*mazelib.LevelMaze); ok {
+		mazelib.PrintLevels(m)
+	} else if !viper.GetBool("compact") {
+		// compact mazes are usually too big to print
+		mazelib.PrintMaze(currentMaze)
+	}
 
-	c.JSON(http.StatusOK, mazelib.Reply{Survey: startRoom})
+	r := mazelib.Reply{Survey: startRoom}
+	if h, ok := currentMaze.(collector); ok {
+		r.Remaining = h.Remaining()
+	}
+	if currentMinotaur != nil {
+		r.Minotaur = senseMinotaur()
+	}
+	r.Hint = hint()
+	if viper.GetBool("reveal-size") || viper.GetBool("gps") || viper.GetBool("torus") {
+		r.Width, r.Height = currentMaze.Width(), currentMaze.Height()
+	}
+	r.Position = gps()
+	r.Modes = modes()
+	return http.StatusOK, r
+}
+
+// modes are the modes of the maze that Icarus needs to know of
+// to pick his solver
+func modes() []string {
+	var m []string
+	if viper.GetInt("one-way") > 0 {
+		m = append(m, mazelib.ModeOneWay)
+	}
+	if viper.GetBool("look") {
+		m = append(m, mazelib.ModeLook)
+	}
+	if viper.GetBool("torus") {
+		// the solver can't tell a room from the same room round the torus
+		// without the size, which wake sends along with it
+		m = append(m, mazelib.ModeTorus)
+	}
+	return m
 }
 
 // MoveDirection is API response to the /move/:direction address
 func MoveDirection(c *gin.Context) {
+	if viper.GetBool("graph") {
+		moveGraph(c)
+		return
+	}
+	if viper.GetBool("hex") {
+		moveHex(c)
+		return
+	}
+	if viper.GetBool("polar") {
+		movePolar(c)
+		return
+	}
+	c.JSON(move(c.Param("direction")))
+}
+
+// move moves Icarus one step in a maze with square rooms and returns the
+// reply to him, with its HTTP status
+func move(direction string) (int, mazelib.Reply) {
+	if err := ready(); err != nil {
+		return refusal(mazelib.Reply{}, err)
+	}
+
 	var err error
 
-	switch c.Param("direction") {
+	switch direction {
 	case "left":
 		err = currentMaze.MoveLeft()
 	case "right":
@@ -130,275 +229,806 @@ func MoveDirection(c *gin.Context) {
 		err = currentMaze.MoveDown()
 	case "up":
 		err = currentMaze.MoveUp()
+	case "ascend":
+		err = currentMaze.MoveAbove()
+	case "descend":
+		err = currentMaze.MoveBelow()
+	default:
+		return refusal(mazelib.Reply{}, mazelib.ErrInvalidDirection)
 	}
 
 	var r mazelib.Reply
 
 	if err != nil {
-		r.Error = true
-		r.Message = err.Error()
-		c.JSON(409, r)
-		return
+		current.bumps++
+		return refusal(r, err)
+	}
+
+	if t, ok := currentMaze.(teleporter); ok {
+		r.Teleported = t.Teleported()
+	}
+	if k := viper.GetInt("shift"); k > 0 && currentMaze.Steps()%k == 0 {
+		r.Shifted = shiftWalls()
+	}
+	if k, ok := currentMaze.(keyHolder); ok {
+		r.Keys = k.Inventory()
+	}
+	if h, ok := currentMaze.(collector); ok {
+		r.Remaining, r.PickedUp = h.Remaining(), h.PickedUp()
 	}
 
 	s, e := currentMaze.LookAround()
 
+	if e == nil && currentMinotaur != nil && moveMinotaur(&r) {
+		return http.StatusOK, r
+	}
+
 	if e != nil {
 		if e == mazelib.ErrVictory {
-			scores = append(scores, currentMaze.StepsTaken)
 			r.Victory = true
-			r.Message = fmt.Sprintf("Victory achieved in %d steps \n", currentMaze.StepsTaken)
+			r.Message = finish(currentMaze.Steps(), currentMaze.Cost())
 		} else {
 			r.Error = true
-			r.Message = err.Error()
+			r.Message = e.Error()
+			r.Code = mazelib.ErrorCode(e)
 		}
+	} else {
+		r.Hint = hint()
+		r.Position = gps()
 	}
 
 	r.Survey = s
 
-	c.JSON(http.StatusOK, r)
+	return http.StatusOK, r
 }
 
-func initializeMaze() {
-	currentMaze = createMaze()
+// Map serves the whole of the current maze with --map, which only
+// privileged solvers should ask for. It doesn't count against the score.
+func Map(c *gin.Context) {
+	var r mazelib.Reply
+	if !viper.GetBool("map") {
+		r.Error = true
+		r.Message = "the map is only served with --map"
+		c.JSON(http.StatusForbidden, r)
+		return
+	}
+	if currentMaze == nil {
+		// Icarus hasn't woken up in a maze yet
+		refuse(c, r, mazelib.ErrSessionEnded)
+		return
+	}
+	if viper.GetBool("graph") || viper.GetBool("hex") || viper.GetBool("polar") || viper.GetInt("levels") != 1 {
+		r.Error = true
+		r.Message = "the map only covers square rooms on a single level"
+		c.JSON(http.StatusConflict, r)
+		return
+	}
+	c.JSON(http.StatusOK, mazelib.MapOf(currentMaze))
 }
 
-// Print to the terminal the average steps to solution for the current session
-func printResults() {
-	fmt.Printf("Labyrinth solved %d times with an avg of %d steps\n", len(scores), mazelib.AvgScores(scores))
+// gps tells Icarus where he is in the maze with --gps
+func gps() *mazelib.Coordinate {
+	if !viper.GetBool("gps") {
+		return nil
+	}
+	x, y := currentMaze.Icarus()
+	return &mazelib.Coordinate{X: x, Y: y}
 }
 
-// GetRoom returns a Room struct
-func (m *Maze) GetRoom(x, y int) (*mazelib.Room, error) {
-	if x < 0 || y < 0 || x >= m.Width() || y >= m.Height() {
-		return &mazelib.Room{}, errors.New("room outside of maze boundaries")
+// hint tells Icarus how close he is to the treasure with --hints,
+// by path or Manhattan distance
+func hint() string {
+	mode := viper.GetString("hints")
+	if mode == "" {
+		return ""
 	}
-
-	return &m.rooms[y][x], nil
+	x, y := currentMaze.Icarus()
+	d := mazelib.Proximity(currentMaze, x, y, mode == "path")
+	return mazelib.Hint(d, currentMaze.Width(), currentMaze.Height(), viper.GetInt("hint-noise"))
 }
 
-// Width returns width of the maze
-func (m *Maze) Width() int { return len(m.rooms[0]) }
+// Look tells Icarus how far he can see down each corridor from
+// his room, and where the treasure is if he can see it. It doesn't move
+// him, but counts against his score.
+func Look(c *gin.Context) {
+	c.JSON(look())
+}
 
-// Height returns height of the maze
-func (m *Maze) Height() int { return len(m.rooms) }
+// look returns what Icarus sees down the corridors, with its HTTP status
+func look() (int, mazelib.Reply) {
+	var r mazelib.Reply
+	if viper.GetBool("graph") || viper.GetBool("hex") || viper.GetBool("polar") {
+		r.Error = true
+		r.Message = "Icarus can only look down the corridors of square rooms"
+		return http.StatusConflict, r
+	}
+	if err := sessionOver(); err != nil {
+		return refusal(r, err)
+	}
 
-// Icarus returns the finder's current position
-func (m *Maze) Icarus() (x, y int) {
-	return m.icarus.X, m.icarus.Y
+	current.looks++
+	x, y := currentMaze.Icarus()
+	sight := mazelib.LineOfSight(currentMaze, x, y)
+	r.Survey, _ = currentMaze.Discover(x, y)
+	r.Sight = &sight
+	r.Position = gps()
+	return http.StatusOK, r
 }
 
-// SetStartPoint sets the location where Icarus will awake
-func (m *Maze) SetStartPoint(x, y int) error {
-	r, err := m.GetRoom(x, y)
-
-	if err != nil {
+// ready returns why Icarus can't move, if the maze is over or he has just
+// run out of steps, or nil if he can
+func ready() error {
+	if err := sessionOver(); err != nil {
 		return err
 	}
+	return outOfSteps()
+}
 
-	if r.Treasure {
-		return errors.New("can't start in the treasure")
+// sessionOver returns why Icarus can't move any more in the current maze,
+// or nil if he can
+func sessionOver() error {
+	switch {
+	case caught:
+		return mazelib.ErrCaught
+	case current.ended || current.start.IsZero():
+		return mazelib.ErrSessionEnded
 	}
-
-	r.Start = true
-	m.icarus = mazelib.Coordinate{x, y}
 	return nil
 }
 
-// SetTreasure sets the location of the treasure for a given maze
-func (m *Maze) SetTreasure(x, y int) error {
-	r, err := m.GetRoom(x, y)
+// outOfSteps ends the current maze, lost, once Icarus has taken the
+// --max-steps, and returns ErrStepLimit
+func outOfSteps() error {
+	limit := viper.GetInt("max-steps")
+	steps := 0
+	switch {
+	case viper.GetBool("graph"):
+		steps = currentGraph.Steps()
+	case viper.GetBool("hex"):
+		steps = currentHex.Steps()
+	case viper.GetBool("polar"):
+		steps = currentPolar.Steps()
+	default:
+		steps = currentMaze.Steps()
+	}
+	if limit <= 0 || steps < limit {
+		return nil
+	}
 
-	if err != nil {
-		return err
+	current.ended = true
+	exhausted++
+	fmt.Println("Icarus ran out of steps")
+	return mazelib.ErrStepLimit
+}
+
+// refuse replies to a request that failed with err, see refusal
+func refuse(c *gin.Context, r mazelib.Reply, err error) {
+	c.JSON(refusal(r, err))
+}
+
+// refusal returns the reply to a request that failed with err, giving its
+// code, and its HTTP status. A bad direction is a bad request, anything
+// else conflicts with the maze.
+func refusal(r mazelib.Reply, err error) (int, mazelib.Reply) {
+	r.Error = true
+	r.Message = err.Error()
+	r.Code = mazelib.ErrorCode(err)
+	r.Caught = err == mazelib.ErrCaught
+	if err == mazelib.ErrInvalidDirection {
+		return http.StatusBadRequest, r
 	}
+	return http.StatusConflict, r
+}
 
-	if r.Start {
-		return errors.New("can't have the treasure at the start")
+// minotaurPace is how many steps Icarus takes for each step of the Minotaur,
+// so that he can outrun it
+const minotaurPace = 2
+
+// moveMinotaur lets the Minotaur take its step after Icarus, and tells
+// Icarus where it is if it is near. It returns if Icarus was caught.
+func moveMinotaur(r *mazelib.Reply) bool {
+	x, y := currentMaze.Icarus()
+	currentMinotaur.Trail(x, y)
+	if !currentMinotaur.Catches(x, y) && currentMaze.Steps()%minotaurPace == 0 {
+		currentMinotaur.Step(currentMaze)
+	}
+	if currentMinotaur.Catches(x, y) {
+		caught = true
+		current.ended = true
+		losses++
+		fmt.Println("Icarus was caught by the Minotaur")
+		r.Caught = true
+		r.Message = mazelib.ErrCaught.Error()
+		r.Code = mazelib.CodeCaught
+		return true
 	}
 
-	r.Treasure = true
-	m.end = mazelib.Coordinate{x, y}
-	return nil
+	r.Minotaur = senseMinotaur()
+	return false
+}
+
+// minotaurRange is how many steps away Icarus hears the Minotaur from
+const minotaurRange = 3
+
+// senseMinotaur returns the move towards the Minotaur, if it is near
+func senseMinotaur() string {
+	x, y := currentMaze.Icarus()
+	if dir, ok := currentMinotaur.Sense(currentMaze, x, y, minotaurRange); ok {
+		return mazelib.SquareMoves[dir]
+	}
+	return ""
 }
 
-// LookAround Given Icarus's current location, Discover that room
-// Will return ErrVictory if Icarus is at the treasure.
-func (m *Maze) LookAround() (mazelib.Survey, error) {
-	if m.end.X == m.icarus.X && m.end.Y == m.icarus.Y {
-		// update statistics
-		ms := mazeStats[(mCount-1)/100]
-		ms.steps += m.StepsTaken
-		ms.times++
+// shiftSize is the size of the square of rooms rearranged by --shift
+const shiftSize = 4
 
-		fmt.Printf("Victory achieved in %d steps \n", m.StepsTaken)
-		return mazelib.Survey{}, mazelib.ErrVictory
+// shiftWalls rearranges the walls of a random square of the current maze
+func shiftWalls() bool {
+	m, ok := currentMaze.(mazelib.Carver)
+	if !ok {
+		return false
+	}
+	size := shiftSize
+	if size > m.Width() {
+		size = m.Width()
 	}
+	if size > m.Height() {
+		size = m.Height()
+	}
+	mazelib.ShiftRegion(m, rand.Intn(m.Width()-size+1), rand.Intn(m.Height()-size+1), size)
+	return true
+}
 
-	return m.Discover(m.icarus.X, m.icarus.Y)
+func initializeMaze() {
+	currentMaze = createMaze()
+	caught = false
+	newSession()
+	if viper.GetInt("levels") == 1 {
+		current.optimum = mazelib.Optimum(currentMaze)
+	}
+	currentMinotaur = nil
+	if mode := viper.GetString("minotaur"); mode != "" {
+		t, err := mazelib.NewMinotaur(currentMaze, mode == "hunt")
+		if err != nil {
+			// the maze is too small, Icarus goes without one this time
+			fmt.Println(err)
+			return
+		}
+		currentMinotaur = t
+	}
 }
 
-// Discover Given two points, survey the room.
-// Will return error if two points are outside of the maze
-func (m *Maze) Discover(x, y int) (mazelib.Survey, error) {
-	r, err := m.GetRoom(x, y)
+// startHex creates a new hexagonal maze and places Icarus in it.
+// The maze is drawn to the --svg file, if given.
+func startHex(c *gin.Context) {
+	currentHex = createHexMaze()
+	newSession()
+	startRoom, err := currentHex.Discover(currentHex.Icarus())
 	if err != nil {
-		return mazelib.Survey{}, err
+		fmt.Println("Icarus is outside of the maze. This shouldn't ever happen")
+		fmt.Println(err)
+		os.Exit(-1)
 	}
-	return r.Walls, nil
+	if path := viper.GetString("svg"); path != "" {
+		if err := drawHex(path, currentHex); err != nil {
+			fmt.Println(err)
+		}
+	}
+
+	c.JSON(http.StatusOK, mazelib.Reply{Hex: &startRoom})
 }
 
-// MoveLeft Moves Icarus's position left one step
-// Will not permit moving through walls or out of the maze
-func (m *Maze) MoveLeft() error {
-	s, e := m.LookAround()
-	if e != nil {
-		return e
+// moveHex is the /move/:direction response for a hexagonal maze.
+// The directions are named as in mazelib.DirectionName, e.g. northeast.
+func moveHex(c *gin.Context) {
+	var r mazelib.Reply
+	if err := ready(); err != nil {
+		refuse(c, r, err)
+		return
+	}
+
+	dir := 0
+	for _, d := range mazelib.Hexagonal.Directions {
+		if mazelib.DirectionName[d] == c.Param("direction") {
+			dir = d
+		}
 	}
-	if s.Left {
-		return errors.New("Can't walk through walls")
+	if dir == 0 {
+		refuse(c, r, mazelib.ErrInvalidDirection)
+		return
 	}
 
-	x, y := m.Icarus()
-	if _, err := m.GetRoom(x-1, y); err != nil {
-		return err
+	if err := currentHex.Move(dir); err != nil {
+		current.bumps++
+		refuse(c, r, err)
+		return
 	}
 
-	m.icarus = mazelib.Coordinate{x - 1, y}
-	m.StepsTaken++
-	return nil
+	s, e := currentHex.LookAround()
+	if e == mazelib.ErrVictory {
+		r.Victory = true
+		r.Message = finish(currentHex.Steps(), currentHex.Cost())
+	}
+
+	r.Hex = &s
+	c.JSON(http.StatusOK, r)
 }
 
-// MoveRight Moves Icarus's position right one step
-// Will not permit moving through walls or out of the maze
-func (m *Maze) MoveRight() error {
-	s, e := m.LookAround()
-	if e != nil {
-		return e
+// startPolar creates a new polar maze and places Icarus in it.
+// The maze is drawn to the --svg file, if given.
+func startPolar(c *gin.Context) {
+	currentPolar = createPolarMaze()
+	newSession()
+	startRoom, err := currentPolar.Discover(currentPolar.Icarus())
+	if err != nil {
+		fmt.Println("Icarus is outside of the maze. This shouldn't ever happen")
+		fmt.Println(err)
+		os.Exit(-1)
 	}
-	if s.Right {
-		return errors.New("Can't walk through walls")
+	if path := viper.GetString("svg"); path != "" {
+		if err := drawPolar(path, currentPolar); err != nil {
+			fmt.Println(err)
+		}
 	}
 
-	x, y := m.Icarus()
-	if _, err := m.GetRoom(x+1, y); err != nil {
-		return err
+	c.JSON(http.StatusOK, mazelib.Reply{Polar: &startRoom})
+}
+
+// movePolar is the /move/:direction response for a polar maze.
+// The directions are named as in mazelib.DirectionName, e.g. clockwise
+// or outward1. The reply tells Icarus the way back.
+func movePolar(c *gin.Context) {
+	var r mazelib.Reply
+	if err := ready(); err != nil {
+		refuse(c, r, err)
+		return
 	}
 
-	m.icarus = mazelib.Coordinate{x + 1, y}
-	m.StepsTaken++
-	return nil
+	dir, ok := mazelib.DirectionByName(c.Param("direction"))
+	if !ok {
+		refuse(c, r, mazelib.ErrInvalidDirection)
+		return
+	}
+
+	if err := currentPolar.Move(dir); err != nil {
+		current.bumps++
+		refuse(c, r, err)
+		return
+	}
+
+	s, e := currentPolar.LookAround()
+	if e == mazelib.ErrVictory {
+		r.Victory = true
+		r.Message = finish(currentPolar.Steps(), currentPolar.Cost())
+	}
+
+	r.Polar = &s
+	r.Back = mazelib.DirectionName[currentPolar.Back()]
+	c.JSON(http.StatusOK, r)
 }
 
-// MoveUp Moves Icarus's position up one step
-// Will not permit moving through walls or out of the maze
-func (m *Maze) MoveUp() error {
-	s, e := m.LookAround()
-	if e != nil {
-		return e
+// createGraphMaze creates a maze of the configured layout and turns it
+// into a graph of rooms with labelled exits
+func createGraphMaze() *mazelib.GraphMaze {
+	switch {
+	case viper.GetBool("hex"):
+		return mazelib.GraphFromHex(createHexMaze())
+	case viper.GetBool("polar"):
+		return mazelib.GraphFromPolar(createPolarMaze())
 	}
-	if s.Top {
-		return errors.New("Can't walk through walls")
+
+	m := createMaze()
+	if lm, ok := m.(*mazelib.LevelMaze); ok {
+		return mazelib.GraphFromLevels(lm)
 	}
+	return mazelib.GraphFromMaze(m)
+}
 
-	x, y := m.Icarus()
-	if _, err := m.GetRoom(x, y-1); err != nil {
-		return err
+// startGraph creates a new maze, served as a graph, and places Icarus in it
+func startGraph(c *gin.Context) {
+	currentGraph = createGraphMaze()
+	newSession()
+	startRoom, err := currentGraph.Discover(currentGraph.Icarus())
+	if err != nil {
+		fmt.Println("Icarus is outside of the maze. This shouldn't ever happen")
+		fmt.Println(err)
+		os.Exit(-1)
 	}
 
-	m.icarus = mazelib.Coordinate{x, y - 1}
-	m.StepsTaken++
-	return nil
+	c.JSON(http.StatusOK, mazelib.Reply{Exits: &startRoom})
 }
 
-// MoveDown Moves Icarus's position down one step
-// Will not permit moving through walls or out of the maze
-func (m *Maze) MoveDown() error {
-	s, e := m.LookAround()
-	if e != nil {
-		return e
+// moveGraph is the /move/:direction response for a maze served as a graph.
+// The direction is the label of an exit. The reply tells Icarus the way back.
+func moveGraph(c *gin.Context) {
+	var r mazelib.Reply
+	if err := ready(); err != nil {
+		refuse(c, r, err)
+		return
 	}
-	if s.Bottom {
-		return errors.New("Can't walk through walls")
+
+	if err := currentGraph.Move(c.Param("direction")); err != nil {
+		if err != mazelib.ErrInvalidDirection {
+			current.bumps++
+		}
+		refuse(c, r, err)
+		return
+	}
+
+	s, e := currentGraph.LookAround()
+	if e == mazelib.ErrVictory {
+		r.Victory = true
+		r.Message = finish(currentGraph.Steps(), currentGraph.Cost())
 	}
 
-	x, y := m.Icarus()
-	if _, err := m.GetRoom(x, y+1); err != nil {
+	r.Exits = &s
+	r.Back = currentGraph.Back()
+	c.JSON(http.StatusOK, r)
+}
+
+// drawHex writes the maze as SVG to the file at path
+func drawHex(path string, m *mazelib.HexMaze) error {
+	f, err := os.Create(path)
+	if err != nil {
 		return err
 	}
+	defer f.Close()
+	return mazelib.WriteHexSVG(f, m)
+}
 
-	m.icarus = mazelib.Coordinate{x, y + 1}
-	m.StepsTaken++
-	return nil
+// drawPolar writes the maze as SVG to the file at path
+func drawPolar(path string, m *mazelib.PolarMaze) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return mazelib.WritePolarSVG(f, m)
+}
+
+// Print to the terminal the average steps to solution for the current session
+// Mazes where steps don't all cost the same are scored by their cost.
+func printResults() {
+	if viper.GetString("minotaur") != "" {
+		fmt.Printf("Icarus was caught by the Minotaur %d times\n", losses)
+	}
+	if exhausted > 0 {
+		fmt.Printf("Icarus ran out of steps %d times\n", exhausted)
+	}
+	printRatio()
+	if printPolicy() {
+		return
+	}
+	if scoredByCost() {
+		fmt.Printf("Labyrinth solved %d times with an avg cost of %d\n", len(scores), mazelib.AvgScores(scores))
+		return
+	}
+	fmt.Printf("Labyrinth solved %d times with an avg of %d steps\n", len(scores), mazelib.AvgScores(scores))
+}
+
+// scoredByCost returns if steps can cost more than 1, because of
+// terrain or stairs
+func scoredByCost() bool {
+	return (viper.GetBool("terrain") || viper.GetInt("levels") > 1) && !viper.GetBool("graph")
+}
+
+// victoryMessage tells Icarus how well he did
+func victoryMessage(steps, cost int) string {
+	s := current
+	s.steps, s.cost, s.elapsed = steps, cost, time.Since(current.start)
+	if p := currentPolicy(); !p.plain(s) {
+		if b := p.breakdown(s); b != "" {
+			return fmt.Sprintf("Victory achieved in %d steps, %s, scoring %d \n", steps, b, p.score(s))
+		}
+		return fmt.Sprintf("Victory achieved in %d steps, scoring %d \n", steps, p.score(s))
+	}
+	if scoredByCost() {
+		return fmt.Sprintf("Victory achieved in %d steps, costing %d \n", steps, cost)
+	}
+	return fmt.Sprintf("Victory achieved in %d steps \n", steps)
 }
 
 // Creates a maze without any walls
 // Good starting point for additive algorithms
-func emptyMaze() *Maze {
-	z := Maze{}
-	ySize := viper.GetInt("height")
-	xSize := viper.GetInt("width")
+func emptyMaze() *mazelib.GridMaze {
+	return mazelib.NewEmptyGrid(viper.GetInt("width"), viper.GetInt("height"))
+}
 
-	z.rooms = make([][]mazelib.Room, ySize)
-	for y := 0; y < ySize; y++ {
-		z.rooms[y] = make([]mazelib.Room, xSize)
-		for x := 0; x < xSize; x++ {
-			z.rooms[y][x] = mazelib.Room{}
+// Creates a maze with all walls
+// Good starting point for subtractive algorithms
+func fullMaze() *mazelib.GridMaze {
+	return mazelib.NewFullGrid(viper.GetInt("width"), viper.GetInt("height"))
+}
+
+// labyrinth is a maze that daedalus can generate and serve.
+// It is either a GridMaze, a CompactMaze when --compact is set,
+// or a LevelMaze when there is more than one level.
+type labyrinth interface {
+	mazelib.MazeI
+	Steps() int
+	Cost() int
+}
+
+// flatLabyrinth is a labyrinth with a single level, which the
+// generators carve through the Carver interface
+type flatLabyrinth interface {
+	labyrinth
+	mazelib.Carver
+}
+
+// teleporter is a labyrinth with portals, which reports when Icarus
+// went through one
+type teleporter interface {
+	Teleported() bool
+}
+
+// keyHolder is a labyrinth with locked doors, which knows the keys
+// Icarus has picked up
+type keyHolder interface {
+	Inventory() []string
+}
+
+// collector is a labyrinth which may have several treasures to pick up
+type collector interface {
+	Remaining() int
+	PickedUp() bool
+}
+
+// newLabyrinth creates a maze of the configured size, with all walls
+// if full is true, otherwise only with the perimeter walls
+// The maze is a torus when --torus is set.
+func newLabyrinth(full bool) flatLabyrinth {
+	w, h := viper.GetInt("width"), viper.GetInt("height")
+	torus := viper.GetBool("torus")
+
+	if viper.GetBool("compact") {
+		switch {
+		case torus && full:
+			return mazelib.NewFullCompactTorus(w, h)
+		case torus:
+			return mazelib.NewCompactTorus(w, h)
+		case full:
+			return mazelib.NewFullCompactMaze(w, h)
 		}
+		return mazelib.NewCompactMaze(w, h)
 	}
 
-	// Add perimeter walls for top and bottom
-	for x := 0; x < xSize; x++ {
-		z.rooms[0][x].AddWall(mazelib.N)
-		z.rooms[ySize-1][x].AddWall(mazelib.S)
+	switch {
+	case torus && full:
+		return mazelib.NewFullTorus(w, h)
+	case torus:
+		return mazelib.NewEmptyTorus(w, h)
+	case full:
+		return fullMaze()
 	}
+	return emptyMaze()
+}
 
-	// Add perimeter walls for left and right
-	for y := 0; y < ySize; y++ {
-		z.rooms[y][0].AddWall(mazelib.W)
-		z.rooms[y][xSize-1].AddWall(mazelib.E)
+// MAZE CREATION CODES STARTS HERE
+
+// generator describes a maze creation algorithm.
+// carve is run on a full maze if full is true, otherwise on an empty maze.
+// carveLevels is run on a full maze with several levels, it is nil
+// if the algorithm only works on a single level. Likewise carveHex and
+// carvePolar are run on full hexagonal and polar mazes.
+type generator struct {
+	full        bool
+	carve       func(m mazelib.Carver)
+	carveLevels func(m *mazelib.LevelMaze)
+	carveHex    func(m *mazelib.HexMaze)
+	carvePolar  func(m *mazelib.PolarMaze)
+}
+
+// generate creates a new maze with the given generator
+// If a shape is loaded, rooms outside of it become rock and the regions
+// the rock cuts apart are joined again.
+func (g generator) generate() labyrinth {
+	if levels := viper.GetInt("levels"); levels > 1 {
+		m := mazelib.NewFullLevels(viper.GetInt("width"), viper.GetInt("height"), levels)
+		g.carveLevels(m)
+		return m
 	}
 
-	return &z
+	m := newLabyrinth(g.full)
+	g.carve(m)
+	if shape != nil {
+		mazelib.ApplyMask(m, shape)
+		mazelib.JoinRegions(m)
+	}
+	if p, ok := m.(mazelib.Painter); ok && viper.GetBool("terrain") {
+		mazelib.PaintRegions(p, 1+m.Width()*m.Height()/30)
+	}
+	if o, ok := m.(mazelib.OneWayCarver); ok && viper.GetInt("one-way") > 0 {
+		mazelib.PlaceOneWays(o, viper.GetInt("one-way"))
+	}
+	if l, ok := m.(mazelib.Linker); ok && viper.GetInt("portals") > 0 {
+		mazelib.PlacePortals(l, viper.GetInt("portals"))
+	}
+	return m
 }
 
-// Creates a maze with all walls
-// Good starting point for subtractive algorithms
-func fullMaze() *Maze {
-	z := emptyMaze()
-	ySize := viper.GetInt("height")
-	xSize := viper.GetInt("width")
+// shape is the mask loaded with --mask, nil if every room is part of the maze
+var shape mazelib.Mask
 
-	for y := 0; y < ySize; y++ {
-		for x := 0; x < xSize; x++ {
-			z.rooms[y][x].Walls = mazelib.Survey{true, true, true, true}
+// loadShape loads the mask given with --mask.
+// The size of the maze becomes the size of the mask.
+func loadShape() error {
+	path := viper.GetString("mask")
+	if path == "" {
+		return nil
+	}
+
+	k, err := mazelib.LoadMask(path)
+	if err != nil {
+		return err
+	}
+	shape = k
+	viper.Set("width", k.Width())
+	viper.Set("height", k.Height())
+	return nil
+}
+
+// squareFlags are the flags that need square rooms on a single level,
+// without --graph, and unless compact is set, without --compact either
+var squareFlags = []struct {
+	name    string
+	compact bool
+}{
+	{"terrain", false},
+	{"one-way", false},
+	{"portals", false},
+	{"keys", false},
+	{"treasures", false},
+	{"minotaur", true},
+	{"hints", true},
+	{"reveal-size", true},
+	{"gps", true},
+	{"shift", true},
+}
+
+// flagInUse returns if the flag turns its mode on: true, a string that
+// isn't empty or a number above its default
+func flagInUse(name string) bool {
+	switch v := viper.Get(name).(type) {
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case int:
+		if name == "treasures" {
+			return v > 1
 		}
+		return v > 0
 	}
+	return false
+}
 
-	return z
+// checkLayout makes sure the other flags can be used with --levels, --hex and --polar
+func checkLayout() error {
+	levels := viper.GetInt("levels")
+	name := viper.GetString("generator")
+	gen, named := generators[name]
+	hex, polar := viper.GetBool("hex"), viper.GetBool("polar")
+	square := levels == 1 && !hex && !polar && !viper.GetBool("graph")
+
+	for _, f := range squareFlags {
+		switch {
+		case !flagInUse(f.name):
+		case f.compact && !square:
+			return fmt.Errorf("--%s only works with square rooms on a single level, without --graph", f.name)
+		case !f.compact && (!square || viper.GetBool("compact")):
+			return fmt.Errorf("--%s only works with square rooms on a single level, without --compact or --graph", f.name)
+		}
+	}
+
+	if viper.GetInt("portals") > 0 && viper.GetInt("one-way") > 0 {
+		return errors.New("--portals and --one-way can't be used together")
+	}
+	if viper.GetInt("keys") > 0 && (viper.GetInt("portals") > 0 || viper.GetInt("one-way") > 0) {
+		return errors.New("--keys can't be used with --portals or --one-way, they could get around the locked doors")
+	}
+	if viper.GetInt("treasures") > 1 && viper.GetInt("keys") > 0 {
+		return errors.New("--treasures and --keys can't be used together")
+	}
+	switch viper.GetString("minotaur") {
+	case "", "wander", "hunt":
+	default:
+		return errors.New("--minotaur must be wander or hunt")
+	}
+	switch viper.GetString("hints") {
+	case "", "manhattan", "path":
+	default:
+		return errors.New("--hints must be manhattan or path")
+	}
+	if viper.GetInt("shift") > 0 && (viper.GetInt("portals") > 0 || viper.GetInt("keys") > 0 || viper.GetInt("one-way") > 0) {
+		return errors.New("--shift can't be used with --portals, --keys or --one-way, which rely on walls that don't move")
+	}
+	if viper.GetString("grpc-port") != "" && (hex || polar || viper.GetBool("graph")) {
+		return errors.New("--grpc-port only works with square rooms, without --graph")
+	}
+	if err := checkPolicy(); err != nil {
+		return err
+	}
+
+	if hex || polar {
+		switch {
+		case hex && polar:
+			return errors.New("--hex and --polar can't be used together")
+		case levels != 1 || viper.GetBool("compact") || viper.GetBool("torus") || viper.GetString("mask") != "":
+			return errors.New("--levels, --compact, --torus and --mask don't work with --hex or --polar")
+		case hex && named && gen.carveHex == nil, polar && named && gen.carvePolar == nil:
+			return fmt.Errorf("generator %q only works with square rooms", name)
+		case polar && viper.GetInt("height") < 2:
+			return errors.New("a polar maze needs at least 2 rings")
+		}
+		return nil
+	}
+
+	switch {
+	case levels < 1:
+		return errors.New("there must be at least 1 level")
+	case levels == 1:
+		return nil
+	case viper.GetBool("compact") || viper.GetBool("torus") || viper.GetString("mask") != "":
+		return errors.New("--compact, --torus and --mask only work with a single level")
+	case named && gen.carveLevels == nil:
+		return fmt.Errorf("generator %q only works with a single level", name)
+	}
+	return nil
 }
 
-// MAZE CREATION CODES STARTS HERE
+// generators are the maze creation algorithms known to daedalus
+var generators = map[string]generator{
+	"kruskal": {full: true, carve: carveKruskal, carveLevels: carveKruskalLevels,
+		carveHex: carveKruskalHex, carvePolar: carveKruskalPolar},
+	"pocket": {full: false, carve: carvePocket},
+	"backtracker": {full: true, carve: carveBacktracker, carveLevels: carveBacktrackerLevels,
+		carveHex: carveBacktrackerHex, carvePolar: carveBacktrackerPolar},
+}
+
+// createHexMaze creates a hexagonal maze with the --generator,
+// or a random generator that can carve hexagons
+func createHexMaze() *mazelib.HexMaze {
+	gen, ok := generators[viper.GetString("generator")]
+	for !ok || gen.carveHex == nil {
+		names, _ := generatorNames()
+		gen = generators[names[rand.Intn(len(names))]]
+		ok = true
+	}
+
+	m := mazelib.NewFullHexMaze(viper.GetInt("width"), viper.GetInt("height"))
+	gen.carveHex(m)
 
-// creates a maze based on Kruskal's algorithm
+	xSize, ySize := m.Width(), m.Height()
+	m.SetStartPoint(rand.Intn(xSize), rand.Intn(ySize))
+	for {
+		if err := m.SetTreasure(rand.Intn(xSize), rand.Intn(ySize)); err == nil {
+			return m
+		}
+	}
+}
+
+// carves a maze based on Kruskal's algorithm
 // http://weblog.jamisbuck.org/2011/1/3/maze-generation-kruskal-s-algorithm
-func createMazeKruskal() *Maze {
+// Sets of connected rooms are kept in a disjoint set forest so that
+// huge mazes can be carved quickly. On a torus, the edges of the maze
+// are joined too.
+func carveKruskal(m mazelib.Carver) {
 	type edge struct {
 		x, y, dir int
 	}
 
-	m := fullMaze()
 	xSize := m.Width()
 	ySize := m.Height()
 
 	// create edges
-	edges := make([]edge, 0, 2*xSize*ySize-ySize-xSize)
+	edges := make([]edge, 0, 2*xSize*ySize)
 	for x := 0; x < xSize; x++ {
 		for y := 0; y < ySize; y++ {
-			if y > 0 {
+			if y > 0 || m.Wraps() {
 				edges = append(edges, edge{x, y, mazelib.N})
 			}
-			if x > 0 {
+			if x > 0 || m.Wraps() {
 				edges = append(edges, edge{x, y, mazelib.W})
 			}
 		}
@@ -410,72 +1040,336 @@ func createMazeKruskal() *Maze {
 		edges[i], edges[j] = edges[j], edges[i]
 	}
 
-	sets := make(map[int]map[mazelib.Coordinate]int, xSize*ySize/2)
+	// every room starts in a set of its own
+	sets := mazelib.NewSets(xSize * ySize)
 
-	for i, edge := range edges {
+	for _, edge := range edges {
 		x, y, dir := edge.x, edge.y, edge.dir
-		thisCoor := mazelib.Coordinate{x, y}
-		nextCoor := mazelib.Coordinate{x + mazelib.Delta[dir].X, y + mazelib.Delta[dir].Y}
+		nx := (x + mazelib.Delta[dir].X + xSize) % xSize
+		ny := (y + mazelib.Delta[dir].Y + ySize) % ySize
+
+		// join the sets and remove the wall linking them,
+		// unless the 2 rooms are in the same set
+		if sets.Union(y*xSize+x, ny*xSize+nx) {
+			m.RmWall(x, y, dir)
+		}
+	}
+}
+
+// carves a maze with Kruskal's algorithm over several levels.
+// Besides the walls between rooms, the floors between levels are
+// edges too, removing one of them adds stairs.
+func carveKruskalLevels(m *mazelib.LevelMaze) {
+	type edge struct {
+		x, y, z, dir int
+	}
+
+	xSize, ySize, zSize := m.Width(), m.Height(), m.Levels()
 
-		thisSetID, nextSetID := -1, -1
-		for id, m := range sets {
-			if _, ok := m[thisCoor]; ok {
-				thisSetID = id
+	// create edges
+	edges := make([]edge, 0, 3*xSize*ySize*zSize)
+	for z := 0; z < zSize; z++ {
+		for y := 0; y < ySize; y++ {
+			for x := 0; x < xSize; x++ {
+				if y > 0 {
+					edges = append(edges, edge{x, y, z, mazelib.N})
+				}
+				if x > 0 {
+					edges = append(edges, edge{x, y, z, mazelib.W})
+				}
+				if z > 0 {
+					edges = append(edges, edge{x, y, z, mazelib.D})
+				}
 			}
-			if _, ok := m[nextCoor]; ok {
-				nextSetID = id
+		}
+	}
+
+	// shuffle the edges
+	for i := range edges {
+		j := rand.Intn(i + 1)
+		edges[i], edges[j] = edges[j], edges[i]
+	}
+
+	// every room starts in a set of its own
+	index := func(x, y, z int) int { return (z*ySize+y)*xSize + x }
+	sets := mazelib.NewSets(xSize * ySize * zSize)
+
+	for _, e := range edges {
+		d := mazelib.Delta[e.dir]
+		if sets.Union(index(e.x, e.y, e.z), index(e.x+d.X, e.y+d.Y, e.z+d.Z)) {
+			m.RmWall3(e.x, e.y, e.z, e.dir)
+		}
+	}
+}
+
+// carves a maze with a recursive backtracker
+// http://weblog.jamisbuck.org/2010/12/27/maze-generation-recursive-backtracking
+// An explicit stack is used instead of recursion so that big mazes
+// don't blow the goroutine stack. On a torus, passages may cross the edges.
+func carveBacktracker(m mazelib.Carver) {
+	xSize := m.Width()
+	ySize := m.Height()
+
+	visited := make([]bool, xSize*ySize)
+	start := mazelib.Coordinate{X: rand.Intn(xSize), Y: rand.Intn(ySize)}
+	visited[start.Y*xSize+start.X] = true
+	stack := []mazelib.Coordinate{start}
+
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		directions := []int{mazelib.N, mazelib.S, mazelib.E, mazelib.W}
+		mazelib.Shuffle(directions)
+
+		moved := false
+		for _, dir := range directions {
+			nx, ny := cur.X+mazelib.Delta[dir].X, cur.Y+mazelib.Delta[dir].Y
+			if m.Wraps() {
+				nx, ny = (nx+xSize)%xSize, (ny+ySize)%ySize
+			} else if nx < 0 || ny < 0 || nx >= xSize || ny >= ySize {
+				continue
 			}
-			if thisSetID >= 0 && nextSetID >= 0 {
-				// found both id
-				break
+			if visited[ny*xSize+nx] {
+				continue
 			}
+			visited[ny*xSize+nx] = true
+			m.RmWall(cur.X, cur.Y, dir)
+			stack = append(stack, mazelib.Coordinate{X: nx, Y: ny})
+			moved = true
+			break
+		}
+		if !moved {
+			stack = stack[:len(stack)-1]
 		}
+	}
+}
 
-		if thisSetID == nextSetID && thisSetID != -1 {
-			// the 2 Coordinate are in the same set, do nothing
-			continue
+// carves a maze with a recursive backtracker over several levels.
+// Stairs are taken like any other passage.
+func carveBacktrackerLevels(m *mazelib.LevelMaze) {
+	xSize, ySize, zSize := m.Width(), m.Height(), m.Levels()
+
+	visited := make(map[mazelib.Coordinate]bool, xSize*ySize*zSize)
+	start := mazelib.Coordinate{X: rand.Intn(xSize), Y: rand.Intn(ySize), Z: rand.Intn(zSize)}
+	visited[start] = true
+	stack := []mazelib.Coordinate{start}
+
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		directions := []int{mazelib.N, mazelib.S, mazelib.E, mazelib.W, mazelib.U, mazelib.D}
+		mazelib.Shuffle(directions)
+
+		moved := false
+		for _, dir := range directions {
+			d := mazelib.Delta[dir]
+			next := mazelib.Coordinate{X: cur.X + d.X, Y: cur.Y + d.Y, Z: cur.Z + d.Z}
+			if _, err := m.GetRoom3(next.X, next.Y, next.Z); err != nil || visited[next] {
+				continue
+			}
+			visited[next] = true
+			m.RmWall3(cur.X, cur.Y, cur.Z, dir)
+			stack = append(stack, next)
+			moved = true
+			break
 		}
+		if !moved {
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
 
-		if thisSetID == nextSetID && thisSetID == -1 {
-			// they are not connected anyway, form a new set
-			newSet := make(map[mazelib.Coordinate]int)
-			newSet[thisCoor], newSet[nextCoor] = 0, 0
-			sets[i] = newSet
-		} else if thisSetID == -1 {
-			// thisCoor will be absorbed into existing set
-			sets[nextSetID][thisCoor] = 0
-		} else if nextSetID == -1 {
-			// nextCoor will be absorbed into existing set
-			sets[thisSetID][nextCoor] = 0
-		} else {
-			// absorb one existing set till another existing set
-			thisSet := sets[thisSetID]
-			nextSet := sets[nextSetID]
+// carves a hexagonal maze with Kruskal's algorithm
+// Only the N, NE and SE sides are edges, the other three sides are
+// the same walls seen from the neighbour.
+func carveKruskalHex(m *mazelib.HexMaze) {
+	type edge struct {
+		x, y, dir int
+	}
+
+	xSize, ySize := m.Width(), m.Height()
 
-			for k, v := range nextSet {
-				thisSet[k] = v
+	edges := make([]edge, 0, 3*xSize*ySize)
+	for y := 0; y < ySize; y++ {
+		for x := 0; x < xSize; x++ {
+			for _, dir := range []int{mazelib.N, mazelib.NE, mazelib.SE} {
+				if _, _, ok := m.Neighbour(x, y, dir); ok {
+					edges = append(edges, edge{x, y, dir})
+				}
 			}
-			delete(sets, nextSetID)
 		}
-		// remove the walls linking to them
-		m.rooms[thisCoor.Y][thisCoor.X].RmWall(dir)
-		m.rooms[nextCoor.Y][nextCoor.X].RmWall(mazelib.Opposite[dir])
 	}
 
-	return m
+	// shuffle the edges
+	for i := range edges {
+		j := rand.Intn(i + 1)
+		edges[i], edges[j] = edges[j], edges[i]
+	}
+
+	// every room starts in a set of its own
+	sets := mazelib.NewSets(xSize * ySize)
+
+	for _, e := range edges {
+		nx, ny, _ := m.Neighbour(e.x, e.y, e.dir)
+		if sets.Union(e.y*xSize+e.x, ny*xSize+nx) {
+			m.RmWall(e.x, e.y, e.dir)
+		}
+	}
+}
+
+// carves a hexagonal maze with a recursive backtracker
+func carveBacktrackerHex(m *mazelib.HexMaze) {
+	xSize, ySize := m.Width(), m.Height()
+
+	visited := make([]bool, xSize*ySize)
+	start := mazelib.Coordinate{X: rand.Intn(xSize), Y: rand.Intn(ySize)}
+	visited[start.Y*xSize+start.X] = true
+	stack := []mazelib.Coordinate{start}
+
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		directions := append([]int(nil), mazelib.Hexagonal.Directions...)
+		mazelib.Shuffle(directions)
+
+		moved := false
+		for _, dir := range directions {
+			nx, ny, ok := m.Neighbour(cur.X, cur.Y, dir)
+			if !ok || visited[ny*xSize+nx] {
+				continue
+			}
+			visited[ny*xSize+nx] = true
+			m.RmWall(cur.X, cur.Y, dir)
+			stack = append(stack, mazelib.Coordinate{X: nx, Y: ny})
+			moved = true
+			break
+		}
+		if !moved {
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
+
+// createPolarMaze creates a polar maze with the --generator,
+// or a random generator that can carve rings. There are as many rings
+// as the height of the maze.
+func createPolarMaze() *mazelib.PolarMaze {
+	gen, ok := generators[viper.GetString("generator")]
+	for !ok || gen.carvePolar == nil {
+		names, _ := generatorNames()
+		gen = generators[names[rand.Intn(len(names))]]
+		ok = true
+	}
+
+	m := mazelib.NewFullPolarMaze(viper.GetInt("height"))
+	gen.carvePolar(m)
+
+	randomRoom := func() (int, int) {
+		ring := rand.Intn(m.Rings())
+		return ring, rand.Intn(m.Cells(ring))
+	}
+	m.SetStartPoint(randomRoom())
+	for {
+		if err := m.SetTreasure(randomRoom()); err == nil {
+			return m
+		}
+	}
+}
+
+// carves a polar maze with Kruskal's algorithm
+// Only the clockwise and inward sides are edges, the other sides are
+// the same walls seen from the neighbour.
+func carveKruskalPolar(m *mazelib.PolarMaze) {
+	type edge struct {
+		ring, cell, dir int
+	}
+
+	// rooms are numbered ring by ring
+	first := make([]int, m.Rings()+1)
+	for r := 0; r < m.Rings(); r++ {
+		first[r+1] = first[r] + m.Cells(r)
+	}
+
+	edges := make([]edge, 0, 2*first[m.Rings()])
+	for r := 0; r < m.Rings(); r++ {
+		for c := 0; c < m.Cells(r); c++ {
+			for _, dir := range []int{mazelib.CW, mazelib.In} {
+				if _, _, ok := m.Neighbour(r, c, dir); ok {
+					edges = append(edges, edge{r, c, dir})
+				}
+			}
+		}
+	}
+
+	// shuffle the edges
+	for i := range edges {
+		j := rand.Intn(i + 1)
+		edges[i], edges[j] = edges[j], edges[i]
+	}
+
+	// every room starts in a set of its own
+	sets := mazelib.NewSets(first[m.Rings()])
+
+	for _, e := range edges {
+		nr, nc, _ := m.Neighbour(e.ring, e.cell, e.dir)
+		if sets.Union(first[e.ring]+e.cell, first[nr]+nc) {
+			m.RmWall(e.ring, e.cell, e.dir)
+		}
+	}
+}
+
+// carves a polar maze with a recursive backtracker
+func carveBacktrackerPolar(m *mazelib.PolarMaze) {
+	visited := make(map[mazelib.Coordinate]bool)
+	ring := rand.Intn(m.Rings())
+	start := mazelib.Coordinate{X: rand.Intn(m.Cells(ring)), Y: ring}
+	visited[start] = true
+	stack := []mazelib.Coordinate{start}
+
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		s, _ := m.Discover(cur.Y, cur.X)
+		directions := []int{mazelib.In, mazelib.CW, mazelib.CCW}
+		for i := range s.Outward {
+			directions = append(directions, mazelib.Outward+i)
+		}
+		mazelib.Shuffle(directions)
+
+		moved := false
+		for _, dir := range directions {
+			nr, nc, ok := m.Neighbour(cur.Y, cur.X, dir)
+			next := mazelib.Coordinate{X: nc, Y: nr}
+			if !ok || visited[next] {
+				continue
+			}
+			visited[next] = true
+			m.RmWall(cur.Y, cur.X, dir)
+			stack = append(stack, next)
+			moved = true
+			break
+		}
+		if !moved {
+			stack = stack[:len(stack)-1]
+		}
+	}
 }
 
-// create a maze full of vertical pockets (tunnels) which
+// carves a maze full of vertical pockets (tunnels) which
 // are either facing up or down
-func createMazePocket() *Maze {
-	m := emptyMaze()
+// Pockets do not wrap around a torus, the edges are walled off.
+func carvePocket(m mazelib.Carver) {
 	xSize := m.Width()
 	ySize := m.Height()
 
+	if m.Wraps() {
+		for x := 0; x < xSize; x++ {
+			m.AddWall(x, 0, mazelib.N)
+		}
+		for y := 0; y < ySize; y++ {
+			m.AddWall(0, y, mazelib.W)
+		}
+	}
+
 	for y := 1; y < ySize-1; y++ {
 		for x := 0; x < xSize-1; x++ {
-			m.rooms[y][x].AddWall(mazelib.E)
-			m.rooms[y][x+1].AddWall(mazelib.W)
+			m.AddWall(x, y, mazelib.E)
 		}
 	}
 
@@ -484,11 +1378,8 @@ func createMazePocket() *Maze {
 		y = ySize - 1
 	}
 	for x := 0; x < xSize-1; x++ {
-		m.rooms[y][x].AddWall(mazelib.E)
-		m.rooms[y][x+1].AddWall(mazelib.W)
+		m.AddWall(x, y, mazelib.E)
 	}
-
-	return m
 }
 
 // some variables to keep track of statistics
@@ -503,11 +1394,23 @@ type mStat struct {
 
 var mazeStats = make([]*mStat, 0)
 
+// recordStats adds the steps taken to solve the current maze to the
+// statistics used by getMaze
+func recordStats(steps int) {
+	if mCount == 0 {
+		// maze was not created by getMaze
+		return
+	}
+	ms := mazeStats[(mCount-1)/100]
+	ms.steps += steps
+	ms.times++
+}
+
 // getMaze changes the maze type for every 100 mazes
 // for the first 100 mazes, use Kruskal
 // for the next 100 mazes, use Pocket
 // subsequent mazes depends on past performance of solver
-func getMaze() *Maze {
+func getMaze() labyrinth {
 	if mCount%100 == 0 {
 		switch mCount / 100 {
 		case 0:
@@ -538,19 +1441,99 @@ func getMaze() *Maze {
 
 	mCount++
 	if nowKruskal {
-		return createMazeKruskal()
+		return generators["kruskal"].generate()
+	}
+	if viper.GetInt("levels") > 1 {
+		// pockets only work on a single level
+		return generators["backtracker"].generate()
+	}
+	return generators["pocket"].generate()
+}
+
+// generatorNames returns the generators selected by the generator flag,
+// or all of them if no generator is given
+func generatorNames() ([]string, error) {
+	if name := viper.GetString("generator"); name != "" {
+		if _, ok := generators[name]; !ok {
+			return nil, fmt.Errorf("unknown generator %q", name)
+		}
+		return []string{name}, nil
+	}
+	names := make([]string, 0, len(generators))
+	for name := range generators {
+		names = append(names, name)
 	}
-	return createMazePocket()
+	sort.Strings(names)
+	return names, nil
 }
 
-func createMaze() *Maze {
-	m := getMaze()
+func createMaze() labyrinth {
+	if name := viper.GetString("generator"); name != "" {
+		if gen, ok := generators[name]; ok {
+			return hideKeys(scatterTreasures(placeIcarus(gen.generate())))
+		}
+	}
+	return hideKeys(scatterTreasures(placeIcarus(getMaze())))
+}
+
+// scatterTreasures adds the other treasures with --treasures
+func scatterTreasures(m labyrinth) labyrinth {
+	if h, ok := m.(mazelib.Hoarder); ok && viper.GetInt("treasures") > 1 {
+		mazelib.ScatterTreasures(h, viper.GetInt("treasures")-1)
+	}
+	return m
+}
+
+// hideKeys locks doors on the way to the treasure and hides their keys,
+// once Icarus and the treasure are in place
+func hideKeys(m labyrinth) labyrinth {
+	if k, ok := m.(mazelib.KeyCarver); ok && viper.GetInt("keys") > 0 {
+		mazelib.PlaceKeys(k, viper.GetInt("keys"))
+	}
+	return m
+}
+
+// placeIcarus sets a random starting point and treasure in the maze
+func placeIcarus(m labyrinth) labyrinth {
 	ySize := m.Height()
 	xSize := m.Width()
 
+	if lm, ok := m.(*mazelib.LevelMaze); ok {
+		// hide the treasure on another level, so that Icarus has to take the stairs
+		sz := rand.Intn(lm.Levels())
+		lm.SetStartPoint3(rand.Intn(xSize), rand.Intn(ySize), sz)
+		tz := (sz + 1 + rand.Intn(lm.Levels()-1)) % lm.Levels()
+		lm.SetTreasure3(rand.Intn(xSize), rand.Intn(ySize), tz)
+		return lm
+	}
+
+	if shape != nil {
+		// the shape may have parts that can't be reached from each
+		// other, the treasure must be in the same part as Icarus
+		for {
+			sx, sy := rand.Intn(xSize), rand.Intn(ySize)
+			if r, _ := m.GetRoom(sx, sy); r.Excluded {
+				continue
+			}
+			rooms := mazelib.Reachable(m, sx, sy)
+			if len(rooms) < 2 || m.SetStartPoint(sx, sy) != nil {
+				continue
+			}
+			for _, i := range rand.Perm(len(rooms)) {
+				if err := m.SetTreasure(rooms[i].X, rooms[i].Y); err == nil {
+					return m
+				}
+			}
+		}
+	}
+
 	// set a startingPoint for Icarus
-	sx, sy := rand.Intn(xSize), rand.Intn(ySize)
-	m.SetStartPoint(sx, sy)
+	for {
+		sx, sy := rand.Intn(xSize), rand.Intn(ySize)
+		if err := m.SetStartPoint(sx, sy); err == nil {
+			break
+		}
+	}
 
 	// set endingPoint (treasure) for Icarus
 	for {
@@ -0,0 +1,238 @@
+// Copyright © 2015 Steve Francia <spf@spf13.com>.
+//
+//   Licensed under the Apache License, Version 2.0 (the "License");
+//   you may not use this file except in compliance with the License.
+//   You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+//
+
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"bitbucket.org/kelvinyong/gc6/mazelib"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+func TestMap(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	defer viper.Set("map", false)
+	defer func(m labyrinth) { currentMaze = m }(currentMaze)
+
+	maze := mazelib.NewFullGrid(3, 2)
+	maze.SetStartPoint(0, 0)
+	maze.SetTreasure(2, 1)
+
+	tests := []struct {
+		name   string
+		served bool
+		maze   labyrinth
+		status int
+		code   string
+	}{
+		{"without --map", false, maze, http.StatusForbidden, ""},
+		{"before /awake", true, nil, http.StatusConflict, mazelib.CodeSessionEnded},
+		{"in a maze", true, maze, http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		viper.Set("map", tt.served)
+		currentMaze = tt.maze
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/map", nil)
+		Map(c)
+
+		if w.Code != tt.status {
+			t.Errorf("%s: status %d, want %d", tt.name, w.Code, tt.status)
+		}
+		if tt.status == http.StatusOK {
+			var m mazelib.Map
+			if err := json.Unmarshal(w.Body.Bytes(), &m); err != nil || m.Width != 3 || m.Height != 2 {
+				t.Errorf("%s: map %+v, error %v", tt.name, m, err)
+			}
+			continue
+		}
+		var r mazelib.Reply
+		if err := json.Unmarshal(w.Body.Bytes(), &r); err != nil || !r.Error || r.Code != tt.code {
+			t.Errorf("%s: reply %+v, error %v, want code %q", tt.name, r, err, tt.code)
+		}
+	}
+}
+
+// setFlags sets the flags for a test, and returns a func that sets them
+// back to what they were
+func setFlags(flags map[string]interface{}) func() {
+	was := make(map[string]interface{}, len(flags))
+	for name, v := range flags {
+		was[name] = viper.Get(name)
+		viper.Set(name, v)
+	}
+	return func() {
+		for name, v := range was {
+			viper.Set(name, v)
+		}
+	}
+}
+
+func TestCheckLayout(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]interface{}
+		ok    bool
+	}{
+		{"square rooms", map[string]interface{}{}, true},
+		{"terrain", map[string]interface{}{"terrain": true}, true},
+		{"terrain on hexagons", map[string]interface{}{"terrain": true, "hex": true}, false},
+		{"compact terrain", map[string]interface{}{"terrain": true, "compact": true}, false},
+		{"compact minotaur", map[string]interface{}{"minotaur": "hunt", "compact": true}, true},
+		{"minotaur on a graph", map[string]interface{}{"minotaur": "hunt", "graph": true}, false},
+		{"one treasure on levels", map[string]interface{}{"treasures": 1, "levels": 2}, true},
+		{"treasures on levels", map[string]interface{}{"treasures": 3, "levels": 2}, false},
+		{"gps on polar rooms", map[string]interface{}{"gps": true, "polar": true}, false},
+		{"portals and one way doors", map[string]interface{}{"portals": 2, "one-way": 2}, false},
+		{"shifting keys", map[string]interface{}{"shift": 5, "keys": 2}, false},
+		{"unknown minotaur", map[string]interface{}{"minotaur": "sleep"}, false},
+		{"hexagons on levels", map[string]interface{}{"hex": true, "levels": 2}, false},
+	}
+
+	for _, tt := range tests {
+		reset := setFlags(tt.flags)
+		err := checkLayout()
+		reset()
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: error %v, want ok %t", tt.name, err, tt.ok)
+		}
+	}
+}
+
+func TestModes(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]interface{}
+		want  []string
+	}{
+		{"plain", map[string]interface{}{}, nil},
+		{"one way doors", map[string]interface{}{"one-way": 3}, []string{mazelib.ModeOneWay}},
+		{"torus", map[string]interface{}{"torus": true}, []string{mazelib.ModeTorus}},
+		{"looking round a torus", map[string]interface{}{"look": true, "torus": true}, []string{mazelib.ModeLook, mazelib.ModeTorus}},
+	}
+
+	for _, tt := range tests {
+		reset := setFlags(tt.flags)
+		got := modes()
+		reset()
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: modes %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMoveMinotaur(t *testing.T) {
+	defer func(m labyrinth, mt *mazelib.Minotaur, s session, l int) {
+		currentMaze, currentMinotaur, current, losses, caught = m, mt, s, l, false
+	}(currentMaze, currentMinotaur, current, losses)
+
+	// the only room half as far as the end of the corridor, and not the
+	// treasure, is (2, 0)
+	maze := mazelib.NewFullGrid(4, 1)
+	for x := 0; x < 3; x++ {
+		maze.RmWall(x, 0, mazelib.E)
+	}
+	maze.SetStartPoint(0, 0)
+	maze.SetTreasure(3, 0)
+	currentMaze = maze
+	mt, err := mazelib.NewMinotaur(maze, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	currentMinotaur = mt
+
+	tests := []struct {
+		name     string
+		move     func() error
+		at       int
+		caught   bool
+		minotaur string
+	}{
+		// it doesn't step after Icarus's first step, only every second
+		{"first step", maze.MoveRight, 2, false, "right"},
+		{"second step", maze.MoveLeft, 1, false, "right"},
+		{"into the Minotaur", maze.MoveRight, 1, true, ""},
+	}
+
+	for _, tt := range tests {
+		if err := tt.move(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		var r mazelib.Reply
+		got := moveMinotaur(&r)
+		if x, _ := mt.At(); x != tt.at || got != tt.caught || r.Caught != tt.caught || r.Minotaur != tt.minotaur {
+			t.Errorf("%s: Minotaur at %d, caught %t, reply %+v", tt.name, x, got, r)
+		}
+	}
+	if !caught || losses == 0 || !current.ended {
+		t.Errorf("caught is %t with %d losses, session ended %t", caught, losses, current.ended)
+	}
+}
+
+func TestWakeReveals(t *testing.T) {
+	defer func(m labyrinth, s session) { currentMaze, current = m, s }(currentMaze, current)
+
+	tests := []struct {
+		name     string
+		flags    map[string]interface{}
+		size     bool
+		position bool
+	}{
+		{"plain", map[string]interface{}{}, false, false},
+		{"reveal size", map[string]interface{}{"reveal-size": true}, true, false},
+		{"gps", map[string]interface{}{"gps": true}, true, true},
+	}
+
+	for _, tt := range tests {
+		tt.flags["width"], tt.flags["height"] = 5, 4
+		reset := setFlags(tt.flags)
+		_, r := wake()
+		w, h := 0, 0
+		if tt.size {
+			w, h = 5, 4
+		}
+		if r.Width != w || r.Height != h {
+			t.Errorf("%s: woke up in a %d x %d maze, want %d x %d", tt.name, r.Width, r.Height, w, h)
+		}
+		x, y := currentMaze.Icarus()
+		if (r.Position != nil) != tt.position || r.Position != nil && *r.Position != (mazelib.Coordinate{X: x, Y: y}) {
+			t.Errorf("%s: woke up at %v, Icarus is at (%d, %d)", tt.name, r.Position, x, y)
+		}
+
+		// the position follows Icarus as he moves
+		open := map[string]bool{"up": !r.Survey.Top, "right": !r.Survey.Right, "down": !r.Survey.Bottom, "left": !r.Survey.Left}
+		for name, ok := range open {
+			if ok {
+				_, mr := move(name)
+				x, y = currentMaze.Icarus()
+				if !mr.Victory && (mr.Position != nil) != tt.position || mr.Position != nil && *mr.Position != (mazelib.Coordinate{X: x, Y: y}) {
+					t.Errorf("%s: moved %s to %v, Icarus is at (%d, %d)", tt.name, name, mr.Position, x, y)
+				}
+				break
+			}
+		}
+		reset()
+	}
+}
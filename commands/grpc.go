@@ -0,0 +1,163 @@
+// Copyright © 2015 Steve Francia <spf@spf13.com>.
+//
+//   Licensed under the Apache License, Version 2.0 (the "License");
+//   you may not use this file except in compliance with the License.
+//   You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+//
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+
+	"bitbucket.org/kelvinyong/gc6/client"
+	"bitbucket.org/kelvinyong/gc6/daedaluspb"
+	"bitbucket.org/kelvinyong/gc6/mazelib"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/spf13/viper"
+)
+
+// daedalusServer serves the Daedalus API over gRPC, with the same
+// handlers as the HTTP API. Failed moves are replies with an error and
+// its code, as over HTTP, not gRPC errors.
+type daedalusServer struct {
+	daedaluspb.UnimplementedDaedalusServer
+}
+
+// serveGRPC serves the gRPC API on the given port, next to the HTTP API
+func serveGRPC(port string) {
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(-1)
+	}
+	s := grpc.NewServer()
+	daedaluspb.RegisterDaedalusServer(s, daedalusServer{})
+	if err := s.Serve(lis); err != nil {
+		fmt.Println(err)
+		os.Exit(-1)
+	}
+}
+
+// Awake creates a new maze and places Icarus in it
+func (daedalusServer) Awake(ctx context.Context, req *daedaluspb.AwakeRequest) (*daedaluspb.Reply, error) {
+	_, r := wake()
+	return daedaluspb.FromReply(r), nil
+}
+
+// Move moves Icarus one step
+func (daedalusServer) Move(ctx context.Context, req *daedaluspb.MoveRequest) (*daedaluspb.Reply, error) {
+	_, r := move(req.GetDirection())
+	return daedaluspb.FromReply(r), nil
+}
+
+// Look looks down the corridors from Icarus's room
+func (daedalusServer) Look(ctx context.Context, req *daedaluspb.LookRequest) (*daedaluspb.Reply, error) {
+	_, r := look()
+	return daedaluspb.FromReply(r), nil
+}
+
+// Done ends the session like /done: Daedalus prints the results and exits
+func (daedalusServer) Done(ctx context.Context, req *daedaluspb.DoneRequest) (*daedaluspb.DoneReply, error) {
+	printResults()
+	os.Exit(1)
+	return &daedaluspb.DoneReply{}, nil
+}
+
+// Play answers each request on the stream in turn, until Icarus closes it
+func (daedalusServer) Play(stream daedaluspb.Daedalus_PlayServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var r mazelib.Reply
+		switch a := req.GetAction().(type) {
+		case *daedaluspb.PlayRequest_Awake:
+			_, r = wake()
+		case *daedaluspb.PlayRequest_Move:
+			_, r = move(a.Move.GetDirection())
+		case *daedaluspb.PlayRequest_Look:
+			_, r = look()
+		default:
+			return status.Error(codes.InvalidArgument, "a request must awake, move or look")
+		}
+		if err := stream.Send(daedaluspb.FromReply(r)); err != nil {
+			return err
+		}
+	}
+}
+
+// grpcTransport carries the requests of Icarus over the Play stream
+type grpcTransport struct {
+	conn   *grpc.ClientConn
+	client daedaluspb.DaedalusClient
+	play   daedaluspb.Daedalus_PlayClient
+}
+
+// dialGRPC connects to Daedalus on the given port and opens a Play stream
+func dialGRPC(port string) (*grpcTransport, error) {
+	conn, err := grpc.NewClient(viper.GetString("host")+":"+port, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	dc := daedaluspb.NewDaedalusClient(conn)
+	play, err := dc.Play(context.Background())
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &grpcTransport{conn: conn, client: dc, play: play}, nil
+}
+
+// send sends a request on the stream and waits for its reply
+func (t *grpcTransport) send(req *daedaluspb.PlayRequest) (mazelib.Reply, error) {
+	if err := t.play.Send(req); err != nil {
+		return mazelib.Reply{}, err
+	}
+	rep, err := t.play.Recv()
+	if err != nil {
+		return mazelib.Reply{}, err
+	}
+	r := rep.ToReply()
+	return r, client.ReplyError(r, 0)
+}
+
+func (t *grpcTransport) awake() (mazelib.Reply, error) {
+	return t.send(&daedaluspb.PlayRequest{Action: &daedaluspb.PlayRequest_Awake{Awake: &daedaluspb.AwakeRequest{}}})
+}
+
+func (t *grpcTransport) move(direction string) (mazelib.Reply, error) {
+	return t.send(&daedaluspb.PlayRequest{Action: &daedaluspb.PlayRequest_Move{Move: &daedaluspb.MoveRequest{Direction: direction}}})
+}
+
+func (t *grpcTransport) look() (mazelib.Reply, error) {
+	return t.send(&daedaluspb.PlayRequest{Action: &daedaluspb.PlayRequest_Look{Look: &daedaluspb.LookRequest{}}})
+}
+
+func (t *grpcTransport) done() {
+	t.play.CloseSend()
+	t.client.Done(context.Background(), &daedaluspb.DoneRequest{})
+	t.conn.Close()
+}
@@ -0,0 +1,104 @@
+// Copyright © 2015 Steve Francia <spf@spf13.com>.
+//
+//   Licensed under the Apache License, Version 2.0 (the "License");
+//   you may not use this file except in compliance with the License.
+//   You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+//
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"bitbucket.org/kelvinyong/gc6/daedaluspb"
+	"bitbucket.org/kelvinyong/gc6/mazelib"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dialBuffer serves the gRPC API in memory and returns a transport
+// playing on it, as dialGRPC would over the network
+func dialBuffer(t *testing.T) (*grpcTransport, func()) {
+	lis := bufconn.Listen(1 << 16)
+	s := grpc.NewServer()
+	daedaluspb.RegisterDaedalusServer(s, daedalusServer{})
+	go s.Serve(lis)
+
+	conn, err := grpc.NewClient("passthrough:///daedalus",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dc := daedaluspb.NewDaedalusClient(conn)
+	play, err := dc.Play(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &grpcTransport{conn: conn, client: dc, play: play}, func() {
+		conn.Close()
+		s.Stop()
+	}
+}
+
+func TestPlay(t *testing.T) {
+	defer func(m labyrinth, s session) { currentMaze, current = m, s }(currentMaze, current)
+	defer setFlags(map[string]interface{}{"width": 4, "height": 3, "reveal-size": true})()
+
+	tr, stop := dialBuffer(t)
+	defer stop()
+
+	start, err := tr.awake()
+	if err != nil || start.Width != 4 || start.Height != 3 {
+		t.Fatalf("awake: reply %+v, error %v", start, err)
+	}
+	// a wall of the start room, if it has any, so the move must fail
+	wall := ""
+	walls := map[string]bool{"up": start.Survey.Top, "right": start.Survey.Right, "down": start.Survey.Bottom, "left": start.Survey.Left}
+	for name, ok := range walls {
+		if ok {
+			wall = name
+		}
+	}
+
+	tests := []struct {
+		name      string
+		direction string
+		is        error
+	}{
+		{"bad direction", "sideways", mazelib.ErrInvalidDirection},
+		{"into a wall", wall, mazelib.ErrWall},
+	}
+	for _, tt := range tests {
+		if tt.direction == "" {
+			continue
+		}
+		r, err := tr.move(tt.direction)
+		if !errors.Is(err, tt.is) || r.Code != mazelib.ErrorCode(tt.is) {
+			t.Errorf("%s: reply %+v, error %v, want %v", tt.name, r, err, tt.is)
+		}
+	}
+
+	// a request that doesn't say what to do ends the stream
+	if err := tr.play.Send(&daedaluspb.PlayRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tr.play.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("empty request: error %v, want %v", err, codes.InvalidArgument)
+	}
+}
@@ -16,12 +16,12 @@
 package commands
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"io/ioutil"
-	"net/http"
+	"os"
 
+	"bitbucket.org/kelvinyong/gc6/client"
 	"bitbucket.org/kelvinyong/gc6/mazelib"
 
 	"github.com/spf13/cobra"
@@ -36,8 +36,9 @@ var icarusCmd = &cobra.Command{
 	Short:   "Start the laybrinth solver",
 	Long: `Icarus wakes up to find himself in the middle of a Labyrinth.
   Due to the darkness of the Labyrinth he can only see his immediate cell and if
-  there is a wall or not to the top, right, bottom and left. He takes one step
-  and then can discover if his new cell has walls on each of the four sides.
+  there is a wall or not to the top, right, bottom and left, and if there are
+  stairs going up or down. He takes one step and then can discover if his new
+  cell has walls on each of the four sides.
 
   Icarus can connect to a Daedalus and solve many laybrinths at a time.`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -51,79 +52,159 @@ func init() {
 
 // RunIcarus runs the solver as many times as the user desires.
 func RunIcarus() {
+	switch viper.GetString("transport") {
+	case "http":
+		daedalus = httpTransport{client.New("http://" + viper.GetString("host") + ":" + viper.GetString("port"))}
+	case "grpc":
+		if viper.GetBool("graph") || viper.GetBool("hex") || viper.GetBool("polar") {
+			fmt.Println("--transport grpc only works with square rooms, without --graph")
+			os.Exit(-1)
+		}
+		if viper.GetString("grpc-port") == "" {
+			fmt.Println("--transport grpc needs the --grpc-port Daedalus serves gRPC on")
+			os.Exit(-1)
+		}
+		t, err := dialGRPC(viper.GetString("grpc-port"))
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(-1)
+		}
+		daedalus = t
+	default:
+		fmt.Println("--transport must be http or grpc")
+		os.Exit(-1)
+	}
+
 	fmt.Println("Solving", viper.GetInt("times"), "times")
 	for x := 0; x < viper.GetInt("times"); x++ {
 		solveMaze()
 	}
 
 	// Once we have solved the maze the required times, tell daedalus we are done
-	makeRequest("http://127.0.0.1:" + viper.GetString("port") + "/done")
+	daedalus.done()
+}
+
+// transport carries the requests of Icarus to Daedalus,
+// over HTTP or over gRPC with --transport grpc. A reply that failed
+// comes with its *client.Error, see client.ReplyError.
+type transport interface {
+	awake() (mazelib.Reply, error)
+	move(direction string) (mazelib.Reply, error)
+	look() (mazelib.Reply, error)
+	done()
+}
+
+// daedalus is the transport to the laybrinth server
+var daedalus transport
+
+// httpTransport carries the requests of Icarus over the HTTP API
+type httpTransport struct {
+	client *client.Client
+}
+
+func (t httpTransport) awake() (mazelib.Reply, error) {
+	return t.client.Awake(context.Background())
+}
+
+func (t httpTransport) move(direction string) (mazelib.Reply, error) {
+	return t.client.Move(context.Background(), direction)
+}
+
+func (t httpTransport) look() (mazelib.Reply, error) {
+	return t.client.Look(context.Background())
+}
+
+func (t httpTransport) done() {
+	t.client.Done(context.Background())
 }
 
 // Make a call to the laybrinth server (daedalus) that icarus is ready to wake up
-func awake() mazelib.Survey {
-	contents, err := makeRequest("http://127.0.0.1:" + viper.GetString("port") + "/awake")
+func awake() mazelib.Reply {
+	r, err := daedalus.awake()
 	if err != nil {
 		fmt.Println(err)
 	}
-	r := ToReply(contents)
-	return r.Survey
+	return r
 }
 
 // Move will make a call to the laybrinth server (daedalus)
 // to move Icarus a given direction
 // Will be used heavily by solveMaze
 func Move(direction string) (mazelib.Survey, error) {
-	if direction == "left" || direction == "right" || direction == "up" || direction == "down" {
+	rep, err := MoveReply(direction)
+	return rep.Survey, err
+}
+
+// MoveReply is Move, but returns the whole reply of the server
+// so that the caller can see if Icarus went through a portal
+// or picked up a key
+func MoveReply(direction string) (mazelib.Reply, error) {
+	if direction == "left" || direction == "right" || direction == "up" || direction == "down" ||
+		direction == "ascend" || direction == "descend" {
 
-		contents, err := makeRequest("http://127.0.0.1:" + viper.GetString("port") + "/move/" + direction)
+		rep, err := daedalus.move(direction)
+		if errors.Is(err, mazelib.ErrCaught) {
+			fmt.Println(rep.Message)
+		}
 		if err != nil {
-			return mazelib.Survey{}, err
+			return rep, err
 		}
 
-		rep := ToReply(contents)
 		if rep.Victory == true {
 			fmt.Println(rep.Message)
 			// os.Exit(1)
-			return rep.Survey, mazelib.ErrVictory
+			return rep, mazelib.ErrVictory
 		}
-		return rep.Survey, errors.New(rep.Message)
+		return rep, nil
 
 	}
 
-	return mazelib.Survey{}, errors.New("invalid direction")
-}
-
-// utility function to wrap making requests to the daedalus server
-func makeRequest(url string) ([]byte, error) {
-	response, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer response.Body.Close()
-	contents, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return nil, err
-	}
-	return contents, nil
+	return mazelib.Reply{}, mazelib.ErrInvalidDirection
 }
 
-// ToReply handles a JSON response and unmarshalling it into a reply struct
-func ToReply(in []byte) mazelib.Reply {
-	res := &mazelib.Reply{}
-	json.Unmarshal(in, &res)
-	return *res
+// LookDown makes a call to the laybrinth server (daedalus) to look down
+// the corridors from Icarus's room. It costs as set by --look-cost.
+func LookDown() (mazelib.Reply, error) {
+	return daedalus.look()
 }
 
 // solveMaze uses solver in mazelib package
 func solveMaze() {
-	survey := awake()
+	if viper.GetBool("graph") {
+		solveGraphMaze()
+		return
+	}
+	if viper.GetBool("hex") {
+		solveHexMaze()
+		return
+	}
+	if viper.GetBool("polar") {
+		solvePolarMaze()
+		return
+	}
+
+	start := awake()
 
 	replies := make(chan mazelib.MazeReply)
-	steps := mazelib.FindTreasure(replies)
-	replies <- mazelib.MazeReply{survey, nil}
+	solver := mazelib.FindTreasure
+	if start.HasMode(mazelib.ModeOneWay) {
+		// one way doors need a solver that knows passages may only go one way
+		solver = mazelib.FindTreasureDirected
+	} else if start.HasMode(mazelib.ModeLook) || viper.GetBool("look") {
+		solver = mazelib.FindTreasureLooking
+	}
+	steps := solver(replies)
+	replies <- mazelib.MazeReply{Survey: start.Survey, Heat: mazelib.Heat(start.Hint),
+		Width: start.Width, Height: start.Height, Position: start.Position, Torus: start.HasMode(mazelib.ModeTorus),
+		Remaining: start.Remaining}
 
 	for step := range steps {
+		if step == mazelib.Look {
+			rep, err := LookDown()
+			replies <- mazelib.MazeReply{Survey: rep.Survey, Sight: rep.Sight, Err: err}
+			continue
+		}
+
 		var dir string
 		switch step {
 		case mazelib.N:
@@ -134,8 +215,134 @@ func solveMaze() {
 			dir = "right"
 		case mazelib.W:
 			dir = "left"
+		case mazelib.U:
+			dir = "ascend"
+		case mazelib.D:
+			dir = "descend"
 		}
-		survey, err := Move(dir)
-		replies <- mazelib.MazeReply{survey, err}
+		rep, err := MoveReply(dir)
+		replies <- mazelib.MazeReply{Survey: rep.Survey, Teleported: rep.Teleported, Shifted: rep.Shifted,
+			Keys: rep.Keys, Danger: danger(rep.Minotaur), Heat: mazelib.Heat(rep.Hint),
+			Position: rep.Position, Remaining: rep.Remaining, PickedUp: rep.PickedUp, Err: err}
+	}
+}
+
+// danger returns the direction of the move towards the Minotaur, or 0
+func danger(move string) int {
+	for dir, name := range mazelib.SquareMoves {
+		if move != "" && name == move {
+			return dir
+		}
+	}
+	return 0
+}
+
+// MoveHex moves Icarus in a hexagonal maze, see Move.
+// The direction is sent by its name in mazelib.DirectionName.
+func MoveHex(direction int) (mazelib.HexSurvey, error) {
+	rep, err := daedalus.move(mazelib.DirectionName[direction])
+	if err != nil {
+		return mazelib.HexSurvey{}, err
+	}
+
+	if rep.Hex == nil {
+		return mazelib.HexSurvey{}, errors.New("Daedalus did not reply with a hexagonal room")
+	}
+	if rep.Victory == true {
+		fmt.Println(rep.Message)
+		return *rep.Hex, mazelib.ErrVictory
+	}
+	return *rep.Hex, nil
+}
+
+// solveHexMaze uses the hexagonal solver in mazelib package
+func solveHexMaze() {
+	r := awake()
+	if r.Hex == nil {
+		fmt.Println("Daedalus did not create a hexagonal maze, is he running with --hex?")
+		os.Exit(-1)
+	}
+
+	replies := make(chan mazelib.HexReply)
+	steps := mazelib.HexTremaux(replies)
+	replies <- mazelib.HexReply{Survey: *r.Hex}
+
+	for step := range steps {
+		survey, err := MoveHex(step)
+		replies <- mazelib.HexReply{Survey: survey, Err: err}
+	}
+}
+
+// MovePolar moves Icarus in a polar maze, see Move.
+// It also returns the direction back to where Icarus came from.
+func MovePolar(direction int) (mazelib.PolarSurvey, int, error) {
+	rep, err := daedalus.move(mazelib.DirectionName[direction])
+	if err != nil {
+		return mazelib.PolarSurvey{}, 0, err
+	}
+
+	if rep.Polar == nil {
+		return mazelib.PolarSurvey{}, 0, errors.New("Daedalus did not reply with a polar room")
+	}
+	back, _ := mazelib.DirectionByName(rep.Back)
+	if rep.Victory == true {
+		fmt.Println(rep.Message)
+		return *rep.Polar, back, mazelib.ErrVictory
+	}
+	return *rep.Polar, back, nil
+}
+
+// solvePolarMaze uses the polar solver in mazelib package
+func solvePolarMaze() {
+	r := awake()
+	if r.Polar == nil {
+		fmt.Println("Daedalus did not create a polar maze, is he running with --polar?")
+		os.Exit(-1)
+	}
+
+	replies := make(chan mazelib.PolarReply)
+	steps := mazelib.PolarBacktracker(replies)
+	replies <- mazelib.PolarReply{Survey: *r.Polar}
+
+	for step := range steps {
+		survey, back, err := MovePolar(step)
+		replies <- mazelib.PolarReply{Survey: survey, Back: back, Err: err}
+	}
+}
+
+// MoveExit moves Icarus through the exit with the given label,
+// in a maze served as a graph. It also returns the label of the way back.
+func MoveExit(label string) (mazelib.ExitSurvey, string, error) {
+	rep, err := daedalus.move(label)
+	if err != nil {
+		return mazelib.ExitSurvey{}, "", err
+	}
+
+	if rep.Exits == nil {
+		return mazelib.ExitSurvey{}, "", errors.New("Daedalus did not reply with the exits of a room")
+	}
+	if rep.Victory == true {
+		fmt.Println(rep.Message)
+		return *rep.Exits, rep.Back, mazelib.ErrVictory
+	}
+	return *rep.Exits, rep.Back, nil
+}
+
+// solveGraphMaze uses the solver for exit labels in mazelib package,
+// which works whatever the layout of the maze
+func solveGraphMaze() {
+	r := awake()
+	if r.Exits == nil {
+		fmt.Println("Daedalus did not serve a graph, is he running with --graph?")
+		os.Exit(-1)
+	}
+
+	replies := make(chan mazelib.GraphReply)
+	steps := mazelib.ExitBacktracker(replies)
+	replies <- mazelib.GraphReply{Survey: *r.Exits}
+
+	for step := range steps {
+		survey, back, err := MoveExit(step)
+		replies <- mazelib.GraphReply{Survey: survey, Back: back, Err: err}
 	}
 }
@@ -71,7 +71,37 @@ func init() {
 	RootCmd.PersistentFlags().IntP("width", "x", 15, "width of the laybrinth")
 	RootCmd.PersistentFlags().IntP("height", "y", 10, "height of the laybrinth") // 'h' is used for help already
 	RootCmd.PersistentFlags().IntP("times", "t", 1, "times to solve the laybrinth")
-	RootCmd.PersistentFlags().IntP("max-steps", "m", 500, "Maximum steps before giving up")
+	RootCmd.PersistentFlags().IntP("max-steps", "m", 500, "Maximum steps before Icarus loses the laybrinth, 0 for no limit")
+	RootCmd.PersistentFlags().Bool("compact", false, "store walls in a compact bitset, for huge laybrinths")
+	RootCmd.PersistentFlags().Bool("torus", false, "wrap the laybrinth around at the edges")
+	RootCmd.PersistentFlags().String("mask", "", "text or PNG file giving the shape of the laybrinth")
+	RootCmd.PersistentFlags().StringP("generator", "g", "", "maze generator to use (default is all of them)")
+	RootCmd.PersistentFlags().IntP("levels", "l", 1, "levels of the laybrinth, joined by stairs")
+	RootCmd.PersistentFlags().Bool("hex", false, "use hexagonal rooms with six sides")
+	RootCmd.PersistentFlags().Bool("polar", false, "use rooms in concentric rings, as many as the height")
+	RootCmd.PersistentFlags().String("svg", "", "file to draw each hexagonal or polar laybrinth in")
+	RootCmd.PersistentFlags().Bool("graph", false, "serve the laybrinth as rooms with labelled exits, whatever its layout")
+	RootCmd.PersistentFlags().Bool("terrain", false, "paint mud and water on the laybrinth, which cost more to cross")
+	RootCmd.PersistentFlags().Int("one-way", 0, "one way doors to add to the laybrinth")
+	RootCmd.PersistentFlags().Int("portals", 0, "pairs of linked rooms to add to the laybrinth")
+	RootCmd.PersistentFlags().Int("keys", 0, "locked doors on the way to the treasure, each with a key to find")
+	RootCmd.PersistentFlags().Int("treasures", 1, "treasures Icarus has to collect to win")
+	RootCmd.PersistentFlags().Int("shift", 0, "rearrange some walls of the laybrinth every N steps")
+	RootCmd.PersistentFlags().String("minotaur", "", "let a Minotaur that can wander or hunt roam the laybrinth")
+	RootCmd.PersistentFlags().String("scoring", "steps", "how Icarus is scored: steps, careful or timed")
+	RootCmd.PersistentFlags().Int("move-cost", 0, "what each step adds to the score, when given instead of the --scoring policy's")
+	RootCmd.PersistentFlags().Int("bump-cost", 0, "what each move into a wall adds to the score, when given instead of the --scoring policy's")
+	RootCmd.PersistentFlags().Int("look-cost", 0, "what looking down the corridors adds to the score, when given instead of the --scoring policy's")
+	RootCmd.PersistentFlags().Int("time-cost", 0, "what each second taken adds to the score, when given instead of the --scoring policy's")
+	RootCmd.PersistentFlags().String("hints", "", "tell Icarus if the treasure is hot, warm or cold by manhattan or path distance")
+	RootCmd.PersistentFlags().Int("hint-noise", 0, "percent chance of a hint being one off")
+	RootCmd.PersistentFlags().Bool("map", false, "serve the whole laybrinth at /map, to privileged solvers")
+	RootCmd.PersistentFlags().Bool("reveal-size", false, "tell Icarus the width and height of the laybrinth when he wakes up")
+	RootCmd.PersistentFlags().Bool("gps", false, "tell Icarus where he is in the laybrinth, and its size, in every reply")
+	RootCmd.PersistentFlags().String("host", "127.0.0.1", "host of Daedalus for Icarus to connect to")
+	RootCmd.PersistentFlags().String("grpc-port", "", "port to serve the gRPC API on, next to HTTP, or for Icarus to connect to")
+	RootCmd.PersistentFlags().String("transport", "http", "how Icarus talks to Daedalus: http or grpc")
+	RootCmd.PersistentFlags().Bool("look", false, "look down the corridors at junctions, Daedalus tells Icarus to when he has it")
 
 	// Bind viper to these flags so viper can read flag values along with config, env, etc.
 	viper.BindPFlag("width", RootCmd.PersistentFlags().Lookup("width"))
@@ -79,6 +109,36 @@ func init() {
 	viper.BindPFlag("port", RootCmd.PersistentFlags().Lookup("port"))
 	viper.BindPFlag("times", RootCmd.PersistentFlags().Lookup("times"))
 	viper.BindPFlag("max-steps", RootCmd.PersistentFlags().Lookup("max-steps"))
+	viper.BindPFlag("compact", RootCmd.PersistentFlags().Lookup("compact"))
+	viper.BindPFlag("torus", RootCmd.PersistentFlags().Lookup("torus"))
+	viper.BindPFlag("mask", RootCmd.PersistentFlags().Lookup("mask"))
+	viper.BindPFlag("generator", RootCmd.PersistentFlags().Lookup("generator"))
+	viper.BindPFlag("levels", RootCmd.PersistentFlags().Lookup("levels"))
+	viper.BindPFlag("hex", RootCmd.PersistentFlags().Lookup("hex"))
+	viper.BindPFlag("polar", RootCmd.PersistentFlags().Lookup("polar"))
+	viper.BindPFlag("svg", RootCmd.PersistentFlags().Lookup("svg"))
+	viper.BindPFlag("graph", RootCmd.PersistentFlags().Lookup("graph"))
+	viper.BindPFlag("terrain", RootCmd.PersistentFlags().Lookup("terrain"))
+	viper.BindPFlag("one-way", RootCmd.PersistentFlags().Lookup("one-way"))
+	viper.BindPFlag("portals", RootCmd.PersistentFlags().Lookup("portals"))
+	viper.BindPFlag("keys", RootCmd.PersistentFlags().Lookup("keys"))
+	viper.BindPFlag("treasures", RootCmd.PersistentFlags().Lookup("treasures"))
+	viper.BindPFlag("shift", RootCmd.PersistentFlags().Lookup("shift"))
+	viper.BindPFlag("minotaur", RootCmd.PersistentFlags().Lookup("minotaur"))
+	viper.BindPFlag("scoring", RootCmd.PersistentFlags().Lookup("scoring"))
+	viper.BindPFlag("move-cost", RootCmd.PersistentFlags().Lookup("move-cost"))
+	viper.BindPFlag("bump-cost", RootCmd.PersistentFlags().Lookup("bump-cost"))
+	viper.BindPFlag("look-cost", RootCmd.PersistentFlags().Lookup("look-cost"))
+	viper.BindPFlag("time-cost", RootCmd.PersistentFlags().Lookup("time-cost"))
+	viper.BindPFlag("hints", RootCmd.PersistentFlags().Lookup("hints"))
+	viper.BindPFlag("hint-noise", RootCmd.PersistentFlags().Lookup("hint-noise"))
+	viper.BindPFlag("map", RootCmd.PersistentFlags().Lookup("map"))
+	viper.BindPFlag("reveal-size", RootCmd.PersistentFlags().Lookup("reveal-size"))
+	viper.BindPFlag("gps", RootCmd.PersistentFlags().Lookup("gps"))
+	viper.BindPFlag("host", RootCmd.PersistentFlags().Lookup("host"))
+	viper.BindPFlag("grpc-port", RootCmd.PersistentFlags().Lookup("grpc-port"))
+	viper.BindPFlag("transport", RootCmd.PersistentFlags().Lookup("transport"))
+	viper.BindPFlag("look", RootCmd.PersistentFlags().Lookup("look"))
 }
 
 // Read in config file and ENV variables if set.
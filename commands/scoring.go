@@ -0,0 +1,195 @@
+// Copyright © 2015 Steve Francia <spf@spf13.com>.
+//
+//   Licensed under the Apache License, Version 2.0 (the "License");
+//   you may not use this file except in compliance with the License.
+//   You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+//
+
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"bitbucket.org/kelvinyong/gc6/mazelib"
+
+	"github.com/spf13/viper"
+)
+
+// scoringPolicy is what each thing Icarus does adds to his score
+type scoringPolicy struct {
+	move   int // each step, times what entering the room costs
+	bump   int // each move that fails, into a wall or a locked door
+	look   int // each look down the corridors
+	second int // each second from waking up to finding the treasure
+}
+
+// policies are the scoring policies known to daedalus.
+// steps is the score of the Go Challenge, where only steps count.
+var policies = map[string]scoringPolicy{
+	"steps":   {move: 1, look: 1},
+	"careful": {move: 1, bump: 5, look: 1},
+	"timed":   {move: 1, look: 1, second: 1000},
+}
+
+// costFlags are the flags that replace the costs of the --scoring policy
+var costFlags = []string{"move-cost", "bump-cost", "look-cost", "time-cost"}
+
+// currentPolicy returns the --scoring policy, with the costs given by
+// --move-cost, --bump-cost, --look-cost and --time-cost instead of its own
+func currentPolicy() scoringPolicy {
+	p := policies[viper.GetString("scoring")]
+	costs := []*int{&p.move, &p.bump, &p.look, &p.second}
+	for i, name := range costFlags {
+		if viper.IsSet(name) {
+			*costs[i] = viper.GetInt(name)
+		}
+	}
+	return p
+}
+
+// policyNames returns the names of the scoring policies, sorted
+func policyNames() []string {
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkPolicy checks the --scoring policy exists, and that the costs
+// given instead of its own aren't negative
+func checkPolicy() error {
+	if _, ok := policies[viper.GetString("scoring")]; !ok {
+		return fmt.Errorf("--scoring must be one of %s", strings.Join(policyNames(), ", "))
+	}
+	for _, name := range costFlags {
+		if viper.IsSet(name) && viper.GetInt(name) < 0 {
+			return fmt.Errorf("--%s can't be negative", name)
+		}
+	}
+	return nil
+}
+
+// session is what Icarus did in the current maze. cost is what his steps
+// cost, which is the number of steps unless some rooms cost more to enter.
+type session struct {
+	steps   int
+	cost    int
+	bumps   int
+	looks   int
+	optimum int // fewest steps to the treasure from the start, if known
+	ended   bool
+	start   time.Time
+	elapsed time.Duration
+}
+
+// current is the session of the current maze, and solved are the
+// sessions of the mazes solved so far
+var current session
+var solved []session
+
+// newSession starts the session of a new maze
+func newSession() {
+	current = session{start: time.Now()}
+}
+
+// score is what the session scores under policy p
+func (p scoringPolicy) score(s session) int {
+	return p.move*s.cost + p.bump*s.bumps + p.look*s.looks + int(float64(p.second)*s.elapsed.Seconds())
+}
+
+// plain returns if the session scores its cost, as it would without
+// a scoring policy
+func (p scoringPolicy) plain(s session) bool {
+	return p.score(s) == s.cost
+}
+
+// breakdown lists what the session did that the policy charges for,
+// besides steps
+func (p scoringPolicy) breakdown(s session) string {
+	parts := make([]string, 0, 3)
+	if p.bump > 0 && s.bumps > 0 {
+		parts = append(parts, fmt.Sprintf("%d bumps", s.bumps))
+	}
+	if p.look > 0 && s.looks > 0 {
+		parts = append(parts, fmt.Sprintf("%d looks", s.looks))
+	}
+	if p.second > 0 {
+		parts = append(parts, s.elapsed.Round(time.Millisecond).String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// finish scores the current session once Icarus has found the treasure,
+// in steps costing cost, and prints and returns the message telling him
+// how he did. It is the one place victories are announced, whatever the
+// layout of the maze.
+func finish(steps, cost int) string {
+	current.steps, current.cost = steps, cost
+	current.elapsed = time.Since(current.start)
+	current.ended = true
+	solved = append(solved, current)
+
+	s := currentPolicy().score(current)
+	scores = append(scores, s)
+	recordStats(s)
+	msg := victoryMessage(steps, cost)
+	fmt.Print(msg)
+	return msg
+}
+
+// printRatio prints how many times the shortest path Icarus took on
+// average, over the mazes where the shortest path is known
+func printRatio() {
+	var ratio float64
+	var optimum, n int
+	for _, s := range solved {
+		if s.optimum > 0 {
+			ratio += float64(s.steps) / float64(s.optimum)
+			optimum += s.optimum
+			n++
+		}
+	}
+	if n > 0 {
+		fmt.Printf("Icarus took %.2f times the shortest path on average, which was %d steps\n", ratio/float64(n), optimum/n)
+	}
+}
+
+// printPolicy prints the scoring policy and what the solved mazes took
+// on average, unless every maze scored just its steps. It returns if it
+// printed anything.
+func printPolicy() bool {
+	p := currentPolicy()
+	var steps, bumps, looks int
+	var elapsed time.Duration
+	plain := true
+	for _, s := range solved {
+		steps += s.steps
+		bumps += s.bumps
+		looks += s.looks
+		elapsed += s.elapsed
+		plain = plain && p.plain(s)
+	}
+	if plain || len(solved) == 0 {
+		return false
+	}
+
+	n := len(solved)
+	fmt.Printf("Scored by the %s policy: %d a step, %d a bump, %d a look, %d a second\n",
+		viper.GetString("scoring"), p.move, p.bump, p.look, p.second)
+	fmt.Printf("Labyrinth solved %d times with an avg score of %d, from %d steps, %d bumps, %d looks and %s\n",
+		n, mazelib.AvgScores(scores), steps/n, bumps/n, looks/n, (elapsed / time.Duration(n)).Round(time.Millisecond))
+	return true
+}
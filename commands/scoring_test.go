@@ -0,0 +1,124 @@
+// Copyright © 2015 Steve Francia <spf@spf13.com>.
+//
+//   Licensed under the Apache License, Version 2.0 (the "License");
+//   you may not use this file except in compliance with the License.
+//   You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+//
+
+package commands
+
+import (
+	"testing"
+	"time"
+)
+
+// setCosts gives the cost flags as if on the command line, and returns
+// a func that takes them back. viper only counts a flag as set when it
+// was changed, which setFlags can't undo.
+func setCosts(costs map[string]string) func() {
+	for name, v := range costs {
+		f := RootCmd.PersistentFlags().Lookup(name)
+		f.Value.Set(v)
+		f.Changed = true
+	}
+	return func() {
+		for name := range costs {
+			f := RootCmd.PersistentFlags().Lookup(name)
+			f.Value.Set(f.DefValue)
+			f.Changed = false
+		}
+	}
+}
+
+func TestCurrentPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		scoring string
+		costs   map[string]string
+		want    scoringPolicy
+	}{
+		{"steps", "steps", nil, scoringPolicy{move: 1, look: 1}},
+		{"careful", "careful", nil, scoringPolicy{move: 1, bump: 5, look: 1}},
+		{"gentler bumps", "careful", map[string]string{"bump-cost": "2"}, scoringPolicy{move: 1, bump: 2, look: 1}},
+		{"free looks", "steps", map[string]string{"look-cost": "0"}, scoringPolicy{move: 1}},
+		{"every cost", "timed", map[string]string{"move-cost": "2", "bump-cost": "3", "look-cost": "4", "time-cost": "5"},
+			scoringPolicy{move: 2, bump: 3, look: 4, second: 5}},
+	}
+
+	for _, tt := range tests {
+		reset := setFlags(map[string]interface{}{"scoring": tt.scoring})
+		unset := setCosts(tt.costs)
+		got := currentPolicy()
+		unset()
+		reset()
+		if got != tt.want {
+			t.Errorf("%s: policy %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCheckPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		scoring string
+		costs   map[string]string
+		ok      bool
+	}{
+		{"steps", "steps", nil, true},
+		{"timed", "timed", nil, true},
+		{"unknown", "fastest", nil, false},
+		{"free bumps", "careful", map[string]string{"bump-cost": "0"}, true},
+		{"negative cost", "careful", map[string]string{"bump-cost": "-1"}, false},
+	}
+
+	for _, tt := range tests {
+		reset := setFlags(map[string]interface{}{"scoring": tt.scoring})
+		unset := setCosts(tt.costs)
+		err := checkPolicy()
+		unset()
+		reset()
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: error %v, want ok %t", tt.name, err, tt.ok)
+		}
+	}
+}
+
+func TestScore(t *testing.T) {
+	s := session{steps: 8, cost: 10, bumps: 2, looks: 3, elapsed: 1500 * time.Millisecond}
+	tests := []struct {
+		policy    string
+		score     int
+		plain     bool
+		breakdown string
+	}{
+		{"steps", 13, false, "3 looks"},
+		{"careful", 23, false, "2 bumps, 3 looks"},
+		{"timed", 1513, false, "3 looks, 1.5s"},
+	}
+
+	for _, tt := range tests {
+		p := policies[tt.policy]
+		if got := p.score(s); got != tt.score {
+			t.Errorf("%s: score %d, want %d", tt.policy, got, tt.score)
+		}
+		if got := p.plain(s); got != tt.plain {
+			t.Errorf("%s: plain is %t, want %t", tt.policy, got, tt.plain)
+		}
+		if got := p.breakdown(s); got != tt.breakdown {
+			t.Errorf("%s: breakdown %q, want %q", tt.policy, got, tt.breakdown)
+		}
+	}
+
+	// without looks or bumps, the steps policy scores just the cost
+	if p := policies["steps"]; !p.plain(session{steps: 8, cost: 10}) || p.breakdown(session{}) != "" {
+		t.Errorf("steps policy isn't plain without looks")
+	}
+}
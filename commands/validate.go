@@ -0,0 +1,95 @@
+// Copyright © 2015 Steve Francia <spf@spf13.com>.
+//
+//   Licensed under the Apache License, Version 2.0 (the "License");
+//   you may not use this file except in compliance with the License.
+//   You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+//   Unless required by applicable law or agreed to in writing, software
+//   distributed under the License is distributed on an "AS IS" BASIS,
+//   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//   See the License for the specific language governing permissions and
+//   limitations under the License.
+//
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"bitbucket.org/kelvinyong/gc6/mazelib"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Defining the validate command.
+// This will be called as 'laybrinth validate'
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the laybrinths created by daedalus",
+	Long: `Validate generates laybrinths the same way daedalus does and checks
+  each of them for one way walls, gaps in the perimeter, unreachable
+  treasure and isolated regions.
+
+  Every generator is checked unless one is given with --generator.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		RunValidate()
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(validateCmd)
+}
+
+// RunValidate validates as many mazes per generator as the user desires.
+func RunValidate() {
+	names, err := generatorNames()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(-1)
+	}
+	if err := loadShape(); err != nil {
+		fmt.Println(err)
+		os.Exit(-1)
+	}
+	if err := checkLayout(); err != nil {
+		fmt.Println(err)
+		os.Exit(-1)
+	}
+	if viper.GetInt("levels") != 1 || viper.GetBool("hex") || viper.GetBool("polar") {
+		fmt.Println("validate only works with square rooms on a single level")
+		os.Exit(-1)
+	}
+
+	failed := false
+	for _, name := range names {
+		var invalid, perfect int
+		for x := 0; x < viper.GetInt("times"); x++ {
+			m := placeIcarus(generators[name].generate())
+			v := mazelib.Validate(m)
+			if v.Perfect {
+				perfect++
+			}
+			valid := v.Valid()
+			if viper.GetInt("one-way") > 0 {
+				valid = v.ValidWithOneWays()
+			}
+			if !valid {
+				invalid++
+				mazelib.PrintMaze(m)
+				fmt.Println(v)
+			}
+		}
+		fmt.Printf("%s: %d mazes, %d invalid, %d perfect\n", name, viper.GetInt("times"), invalid, perfect)
+		if invalid > 0 {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
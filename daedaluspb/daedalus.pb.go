@@ -0,0 +1,944 @@
+// By Kelvin Yong for Go Challenge 6
+//
+// The Daedalus API over gRPC, next to the HTTP one. It serves mazes with
+// square rooms, and its replies carry the same fields as mazelib.Reply.
+//
+// daedalus.pb.go and daedalus_grpc.pb.go are generated from this file,
+// regenerate them with go generate, see generate.go.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: daedalus.proto
+
+package daedaluspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AwakeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AwakeRequest) Reset() {
+	*x = AwakeRequest{}
+	mi := &file_daedalus_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AwakeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AwakeRequest) ProtoMessage() {}
+
+func (x *AwakeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daedalus_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AwakeRequest.ProtoReflect.Descriptor instead.
+func (*AwakeRequest) Descriptor() ([]byte, []int) {
+	return file_daedalus_proto_rawDescGZIP(), []int{0}
+}
+
+type MoveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Direction     string                 `protobuf:"bytes,1,opt,name=direction,proto3" json:"direction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveRequest) Reset() {
+	*x = MoveRequest{}
+	mi := &file_daedalus_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveRequest) ProtoMessage() {}
+
+func (x *MoveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daedalus_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveRequest.ProtoReflect.Descriptor instead.
+func (*MoveRequest) Descriptor() ([]byte, []int) {
+	return file_daedalus_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MoveRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+type LookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookRequest) Reset() {
+	*x = LookRequest{}
+	mi := &file_daedalus_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookRequest) ProtoMessage() {}
+
+func (x *LookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daedalus_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookRequest.ProtoReflect.Descriptor instead.
+func (*LookRequest) Descriptor() ([]byte, []int) {
+	return file_daedalus_proto_rawDescGZIP(), []int{2}
+}
+
+type DoneRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DoneRequest) Reset() {
+	*x = DoneRequest{}
+	mi := &file_daedalus_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DoneRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DoneRequest) ProtoMessage() {}
+
+func (x *DoneRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daedalus_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DoneRequest.ProtoReflect.Descriptor instead.
+func (*DoneRequest) Descriptor() ([]byte, []int) {
+	return file_daedalus_proto_rawDescGZIP(), []int{3}
+}
+
+type DoneReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DoneReply) Reset() {
+	*x = DoneReply{}
+	mi := &file_daedalus_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DoneReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DoneReply) ProtoMessage() {}
+
+func (x *DoneReply) ProtoReflect() protoreflect.Message {
+	mi := &file_daedalus_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DoneReply.ProtoReflect.Descriptor instead.
+func (*DoneReply) Descriptor() ([]byte, []int) {
+	return file_daedalus_proto_rawDescGZIP(), []int{4}
+}
+
+type PlayRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Action:
+	//
+	//	*PlayRequest_Awake
+	//	*PlayRequest_Move
+	//	*PlayRequest_Look
+	Action        isPlayRequest_Action `protobuf_oneof:"action"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayRequest) Reset() {
+	*x = PlayRequest{}
+	mi := &file_daedalus_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayRequest) ProtoMessage() {}
+
+func (x *PlayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daedalus_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayRequest.ProtoReflect.Descriptor instead.
+func (*PlayRequest) Descriptor() ([]byte, []int) {
+	return file_daedalus_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PlayRequest) GetAction() isPlayRequest_Action {
+	if x != nil {
+		return x.Action
+	}
+	return nil
+}
+
+func (x *PlayRequest) GetAwake() *AwakeRequest {
+	if x != nil {
+		if x, ok := x.Action.(*PlayRequest_Awake); ok {
+			return x.Awake
+		}
+	}
+	return nil
+}
+
+func (x *PlayRequest) GetMove() *MoveRequest {
+	if x != nil {
+		if x, ok := x.Action.(*PlayRequest_Move); ok {
+			return x.Move
+		}
+	}
+	return nil
+}
+
+func (x *PlayRequest) GetLook() *LookRequest {
+	if x != nil {
+		if x, ok := x.Action.(*PlayRequest_Look); ok {
+			return x.Look
+		}
+	}
+	return nil
+}
+
+type isPlayRequest_Action interface {
+	isPlayRequest_Action()
+}
+
+type PlayRequest_Awake struct {
+	Awake *AwakeRequest `protobuf:"bytes,1,opt,name=awake,proto3,oneof"`
+}
+
+type PlayRequest_Move struct {
+	Move *MoveRequest `protobuf:"bytes,2,opt,name=move,proto3,oneof"`
+}
+
+type PlayRequest_Look struct {
+	Look *LookRequest `protobuf:"bytes,3,opt,name=look,proto3,oneof"`
+}
+
+func (*PlayRequest_Awake) isPlayRequest_Action() {}
+
+func (*PlayRequest_Move) isPlayRequest_Action() {}
+
+func (*PlayRequest_Look) isPlayRequest_Action() {}
+
+// Survey is the walls of a room. True indicates a wall is present.
+type Survey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Top           bool                   `protobuf:"varint,1,opt,name=top,proto3" json:"top,omitempty"`
+	Right         bool                   `protobuf:"varint,2,opt,name=right,proto3" json:"right,omitempty"`
+	Bottom        bool                   `protobuf:"varint,3,opt,name=bottom,proto3" json:"bottom,omitempty"`
+	Left          bool                   `protobuf:"varint,4,opt,name=left,proto3" json:"left,omitempty"`
+	Up            bool                   `protobuf:"varint,5,opt,name=up,proto3" json:"up,omitempty"`
+	Down          bool                   `protobuf:"varint,6,opt,name=down,proto3" json:"down,omitempty"`
+	TopCost       int32                  `protobuf:"varint,7,opt,name=top_cost,json=topCost,proto3" json:"top_cost,omitempty"`
+	RightCost     int32                  `protobuf:"varint,8,opt,name=right_cost,json=rightCost,proto3" json:"right_cost,omitempty"`
+	BottomCost    int32                  `protobuf:"varint,9,opt,name=bottom_cost,json=bottomCost,proto3" json:"bottom_cost,omitempty"`
+	LeftCost      int32                  `protobuf:"varint,10,opt,name=left_cost,json=leftCost,proto3" json:"left_cost,omitempty"`
+	TopLock       string                 `protobuf:"bytes,11,opt,name=top_lock,json=topLock,proto3" json:"top_lock,omitempty"`
+	RightLock     string                 `protobuf:"bytes,12,opt,name=right_lock,json=rightLock,proto3" json:"right_lock,omitempty"`
+	BottomLock    string                 `protobuf:"bytes,13,opt,name=bottom_lock,json=bottomLock,proto3" json:"bottom_lock,omitempty"`
+	LeftLock      string                 `protobuf:"bytes,14,opt,name=left_lock,json=leftLock,proto3" json:"left_lock,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Survey) Reset() {
+	*x = Survey{}
+	mi := &file_daedalus_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Survey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Survey) ProtoMessage() {}
+
+func (x *Survey) ProtoReflect() protoreflect.Message {
+	mi := &file_daedalus_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Survey.ProtoReflect.Descriptor instead.
+func (*Survey) Descriptor() ([]byte, []int) {
+	return file_daedalus_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Survey) GetTop() bool {
+	if x != nil {
+		return x.Top
+	}
+	return false
+}
+
+func (x *Survey) GetRight() bool {
+	if x != nil {
+		return x.Right
+	}
+	return false
+}
+
+func (x *Survey) GetBottom() bool {
+	if x != nil {
+		return x.Bottom
+	}
+	return false
+}
+
+func (x *Survey) GetLeft() bool {
+	if x != nil {
+		return x.Left
+	}
+	return false
+}
+
+func (x *Survey) GetUp() bool {
+	if x != nil {
+		return x.Up
+	}
+	return false
+}
+
+func (x *Survey) GetDown() bool {
+	if x != nil {
+		return x.Down
+	}
+	return false
+}
+
+func (x *Survey) GetTopCost() int32 {
+	if x != nil {
+		return x.TopCost
+	}
+	return 0
+}
+
+func (x *Survey) GetRightCost() int32 {
+	if x != nil {
+		return x.RightCost
+	}
+	return 0
+}
+
+func (x *Survey) GetBottomCost() int32 {
+	if x != nil {
+		return x.BottomCost
+	}
+	return 0
+}
+
+func (x *Survey) GetLeftCost() int32 {
+	if x != nil {
+		return x.LeftCost
+	}
+	return 0
+}
+
+func (x *Survey) GetTopLock() string {
+	if x != nil {
+		return x.TopLock
+	}
+	return ""
+}
+
+func (x *Survey) GetRightLock() string {
+	if x != nil {
+		return x.RightLock
+	}
+	return ""
+}
+
+func (x *Survey) GetBottomLock() string {
+	if x != nil {
+		return x.BottomLock
+	}
+	return ""
+}
+
+func (x *Survey) GetLeftLock() string {
+	if x != nil {
+		return x.LeftLock
+	}
+	return ""
+}
+
+type Coordinate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             int32                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	Z             int32                  `protobuf:"varint,3,opt,name=z,proto3" json:"z,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Coordinate) Reset() {
+	*x = Coordinate{}
+	mi := &file_daedalus_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coordinate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coordinate) ProtoMessage() {}
+
+func (x *Coordinate) ProtoReflect() protoreflect.Message {
+	mi := &file_daedalus_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coordinate.ProtoReflect.Descriptor instead.
+func (*Coordinate) Descriptor() ([]byte, []int) {
+	return file_daedalus_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Coordinate) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Coordinate) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Coordinate) GetZ() int32 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+type Sight struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Top           int32                  `protobuf:"varint,1,opt,name=top,proto3" json:"top,omitempty"`
+	Right         int32                  `protobuf:"varint,2,opt,name=right,proto3" json:"right,omitempty"`
+	Bottom        int32                  `protobuf:"varint,3,opt,name=bottom,proto3" json:"bottom,omitempty"`
+	Left          int32                  `protobuf:"varint,4,opt,name=left,proto3" json:"left,omitempty"`
+	Treasure      string                 `protobuf:"bytes,5,opt,name=treasure,proto3" json:"treasure,omitempty"`
+	Distance      int32                  `protobuf:"varint,6,opt,name=distance,proto3" json:"distance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sight) Reset() {
+	*x = Sight{}
+	mi := &file_daedalus_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sight) ProtoMessage() {}
+
+func (x *Sight) ProtoReflect() protoreflect.Message {
+	mi := &file_daedalus_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sight.ProtoReflect.Descriptor instead.
+func (*Sight) Descriptor() ([]byte, []int) {
+	return file_daedalus_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Sight) GetTop() int32 {
+	if x != nil {
+		return x.Top
+	}
+	return 0
+}
+
+func (x *Sight) GetRight() int32 {
+	if x != nil {
+		return x.Right
+	}
+	return 0
+}
+
+func (x *Sight) GetBottom() int32 {
+	if x != nil {
+		return x.Bottom
+	}
+	return 0
+}
+
+func (x *Sight) GetLeft() int32 {
+	if x != nil {
+		return x.Left
+	}
+	return 0
+}
+
+func (x *Sight) GetTreasure() string {
+	if x != nil {
+		return x.Treasure
+	}
+	return ""
+}
+
+func (x *Sight) GetDistance() int32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type Reply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Survey        *Survey                `protobuf:"bytes,1,opt,name=survey,proto3" json:"survey,omitempty"`
+	Teleported    bool                   `protobuf:"varint,2,opt,name=teleported,proto3" json:"teleported,omitempty"`
+	Shifted       bool                   `protobuf:"varint,3,opt,name=shifted,proto3" json:"shifted,omitempty"`
+	Keys          []string               `protobuf:"bytes,4,rep,name=keys,proto3" json:"keys,omitempty"`
+	Remaining     int32                  `protobuf:"varint,5,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	PickedUp      bool                   `protobuf:"varint,6,opt,name=picked_up,json=pickedUp,proto3" json:"picked_up,omitempty"`
+	Minotaur      string                 `protobuf:"bytes,7,opt,name=minotaur,proto3" json:"minotaur,omitempty"`
+	Caught        bool                   `protobuf:"varint,8,opt,name=caught,proto3" json:"caught,omitempty"`
+	Code          string                 `protobuf:"bytes,9,opt,name=code,proto3" json:"code,omitempty"`
+	Sight         *Sight                 `protobuf:"bytes,10,opt,name=sight,proto3" json:"sight,omitempty"`
+	Hint          string                 `protobuf:"bytes,11,opt,name=hint,proto3" json:"hint,omitempty"`
+	Width         int32                  `protobuf:"varint,12,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,13,opt,name=height,proto3" json:"height,omitempty"`
+	Position      *Coordinate            `protobuf:"bytes,14,opt,name=position,proto3" json:"position,omitempty"`
+	Victory       bool                   `protobuf:"varint,15,opt,name=victory,proto3" json:"victory,omitempty"`
+	Message       string                 `protobuf:"bytes,16,opt,name=message,proto3" json:"message,omitempty"`
+	Error         bool                   `protobuf:"varint,17,opt,name=error,proto3" json:"error,omitempty"`
+	Modes         []string               `protobuf:"bytes,18,rep,name=modes,proto3" json:"modes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reply) Reset() {
+	*x = Reply{}
+	mi := &file_daedalus_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reply) ProtoMessage() {}
+
+func (x *Reply) ProtoReflect() protoreflect.Message {
+	mi := &file_daedalus_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reply.ProtoReflect.Descriptor instead.
+func (*Reply) Descriptor() ([]byte, []int) {
+	return file_daedalus_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Reply) GetSurvey() *Survey {
+	if x != nil {
+		return x.Survey
+	}
+	return nil
+}
+
+func (x *Reply) GetTeleported() bool {
+	if x != nil {
+		return x.Teleported
+	}
+	return false
+}
+
+func (x *Reply) GetShifted() bool {
+	if x != nil {
+		return x.Shifted
+	}
+	return false
+}
+
+func (x *Reply) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *Reply) GetRemaining() int32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *Reply) GetPickedUp() bool {
+	if x != nil {
+		return x.PickedUp
+	}
+	return false
+}
+
+func (x *Reply) GetMinotaur() string {
+	if x != nil {
+		return x.Minotaur
+	}
+	return ""
+}
+
+func (x *Reply) GetCaught() bool {
+	if x != nil {
+		return x.Caught
+	}
+	return false
+}
+
+func (x *Reply) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Reply) GetSight() *Sight {
+	if x != nil {
+		return x.Sight
+	}
+	return nil
+}
+
+func (x *Reply) GetHint() string {
+	if x != nil {
+		return x.Hint
+	}
+	return ""
+}
+
+func (x *Reply) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Reply) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Reply) GetPosition() *Coordinate {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *Reply) GetVictory() bool {
+	if x != nil {
+		return x.Victory
+	}
+	return false
+}
+
+func (x *Reply) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Reply) GetError() bool {
+	if x != nil {
+		return x.Error
+	}
+	return false
+}
+
+func (x *Reply) GetModes() []string {
+	if x != nil {
+		return x.Modes
+	}
+	return nil
+}
+
+var File_daedalus_proto protoreflect.FileDescriptor
+
+const file_daedalus_proto_rawDesc = "" +
+	"\n" +
+	"\x0edaedalus.proto\x12\bdaedalus\"\x0e\n" +
+	"\fAwakeRequest\"+\n" +
+	"\vMoveRequest\x12\x1c\n" +
+	"\tdirection\x18\x01 \x01(\tR\tdirection\"\r\n" +
+	"\vLookRequest\"\r\n" +
+	"\vDoneRequest\"\v\n" +
+	"\tDoneReply\"\xa1\x01\n" +
+	"\vPlayRequest\x12.\n" +
+	"\x05awake\x18\x01 \x01(\v2\x16.daedalus.AwakeRequestH\x00R\x05awake\x12+\n" +
+	"\x04move\x18\x02 \x01(\v2\x15.daedalus.MoveRequestH\x00R\x04move\x12+\n" +
+	"\x04look\x18\x03 \x01(\v2\x15.daedalus.LookRequestH\x00R\x04lookB\b\n" +
+	"\x06action\"\xf0\x02\n" +
+	"\x06Survey\x12\x10\n" +
+	"\x03top\x18\x01 \x01(\bR\x03top\x12\x14\n" +
+	"\x05right\x18\x02 \x01(\bR\x05right\x12\x16\n" +
+	"\x06bottom\x18\x03 \x01(\bR\x06bottom\x12\x12\n" +
+	"\x04left\x18\x04 \x01(\bR\x04left\x12\x0e\n" +
+	"\x02up\x18\x05 \x01(\bR\x02up\x12\x12\n" +
+	"\x04down\x18\x06 \x01(\bR\x04down\x12\x19\n" +
+	"\btop_cost\x18\a \x01(\x05R\atopCost\x12\x1d\n" +
+	"\n" +
+	"right_cost\x18\b \x01(\x05R\trightCost\x12\x1f\n" +
+	"\vbottom_cost\x18\t \x01(\x05R\n" +
+	"bottomCost\x12\x1b\n" +
+	"\tleft_cost\x18\n" +
+	" \x01(\x05R\bleftCost\x12\x19\n" +
+	"\btop_lock\x18\v \x01(\tR\atopLock\x12\x1d\n" +
+	"\n" +
+	"right_lock\x18\f \x01(\tR\trightLock\x12\x1f\n" +
+	"\vbottom_lock\x18\r \x01(\tR\n" +
+	"bottomLock\x12\x1b\n" +
+	"\tleft_lock\x18\x0e \x01(\tR\bleftLock\"6\n" +
+	"\n" +
+	"Coordinate\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x05R\x01y\x12\f\n" +
+	"\x01z\x18\x03 \x01(\x05R\x01z\"\x93\x01\n" +
+	"\x05Sight\x12\x10\n" +
+	"\x03top\x18\x01 \x01(\x05R\x03top\x12\x14\n" +
+	"\x05right\x18\x02 \x01(\x05R\x05right\x12\x16\n" +
+	"\x06bottom\x18\x03 \x01(\x05R\x06bottom\x12\x12\n" +
+	"\x04left\x18\x04 \x01(\x05R\x04left\x12\x1a\n" +
+	"\btreasure\x18\x05 \x01(\tR\btreasure\x12\x1a\n" +
+	"\bdistance\x18\x06 \x01(\x05R\bdistance\"\xfd\x03\n" +
+	"\x05Reply\x12(\n" +
+	"\x06survey\x18\x01 \x01(\v2\x10.daedalus.SurveyR\x06survey\x12\x1e\n" +
+	"\n" +
+	"teleported\x18\x02 \x01(\bR\n" +
+	"teleported\x12\x18\n" +
+	"\ashifted\x18\x03 \x01(\bR\ashifted\x12\x12\n" +
+	"\x04keys\x18\x04 \x03(\tR\x04keys\x12\x1c\n" +
+	"\tremaining\x18\x05 \x01(\x05R\tremaining\x12\x1b\n" +
+	"\tpicked_up\x18\x06 \x01(\bR\bpickedUp\x12\x1a\n" +
+	"\bminotaur\x18\a \x01(\tR\bminotaur\x12\x16\n" +
+	"\x06caught\x18\b \x01(\bR\x06caught\x12\x12\n" +
+	"\x04code\x18\t \x01(\tR\x04code\x12%\n" +
+	"\x05sight\x18\n" +
+	" \x01(\v2\x0f.daedalus.SightR\x05sight\x12\x12\n" +
+	"\x04hint\x18\v \x01(\tR\x04hint\x12\x14\n" +
+	"\x05width\x18\f \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\r \x01(\x05R\x06height\x120\n" +
+	"\bposition\x18\x0e \x01(\v2\x14.daedalus.CoordinateR\bposition\x12\x18\n" +
+	"\avictory\x18\x0f \x01(\bR\avictory\x12\x18\n" +
+	"\amessage\x18\x10 \x01(\tR\amessage\x12\x14\n" +
+	"\x05error\x18\x11 \x01(\bR\x05error\x12\x14\n" +
+	"\x05modes\x18\x12 \x03(\tR\x05modes2\x84\x02\n" +
+	"\bDaedalus\x120\n" +
+	"\x05Awake\x12\x16.daedalus.AwakeRequest\x1a\x0f.daedalus.Reply\x12.\n" +
+	"\x04Move\x12\x15.daedalus.MoveRequest\x1a\x0f.daedalus.Reply\x12.\n" +
+	"\x04Look\x12\x15.daedalus.LookRequest\x1a\x0f.daedalus.Reply\x122\n" +
+	"\x04Done\x12\x15.daedalus.DoneRequest\x1a\x13.daedalus.DoneReply\x122\n" +
+	"\x04Play\x12\x15.daedalus.PlayRequest\x1a\x0f.daedalus.Reply(\x010\x01B)Z'bitbucket.org/kelvinyong/gc6/daedaluspbb\x06proto3"
+
+var (
+	file_daedalus_proto_rawDescOnce sync.Once
+	file_daedalus_proto_rawDescData []byte
+)
+
+func file_daedalus_proto_rawDescGZIP() []byte {
+	file_daedalus_proto_rawDescOnce.Do(func() {
+		file_daedalus_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_daedalus_proto_rawDesc), len(file_daedalus_proto_rawDesc)))
+	})
+	return file_daedalus_proto_rawDescData
+}
+
+var file_daedalus_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_daedalus_proto_goTypes = []any{
+	(*AwakeRequest)(nil), // 0: daedalus.AwakeRequest
+	(*MoveRequest)(nil),  // 1: daedalus.MoveRequest
+	(*LookRequest)(nil),  // 2: daedalus.LookRequest
+	(*DoneRequest)(nil),  // 3: daedalus.DoneRequest
+	(*DoneReply)(nil),    // 4: daedalus.DoneReply
+	(*PlayRequest)(nil),  // 5: daedalus.PlayRequest
+	(*Survey)(nil),       // 6: daedalus.Survey
+	(*Coordinate)(nil),   // 7: daedalus.Coordinate
+	(*Sight)(nil),        // 8: daedalus.Sight
+	(*Reply)(nil),        // 9: daedalus.Reply
+}
+var file_daedalus_proto_depIdxs = []int32{
+	0,  // 0: daedalus.PlayRequest.awake:type_name -> daedalus.AwakeRequest
+	1,  // 1: daedalus.PlayRequest.move:type_name -> daedalus.MoveRequest
+	2,  // 2: daedalus.PlayRequest.look:type_name -> daedalus.LookRequest
+	6,  // 3: daedalus.Reply.survey:type_name -> daedalus.Survey
+	8,  // 4: daedalus.Reply.sight:type_name -> daedalus.Sight
+	7,  // 5: daedalus.Reply.position:type_name -> daedalus.Coordinate
+	0,  // 6: daedalus.Daedalus.Awake:input_type -> daedalus.AwakeRequest
+	1,  // 7: daedalus.Daedalus.Move:input_type -> daedalus.MoveRequest
+	2,  // 8: daedalus.Daedalus.Look:input_type -> daedalus.LookRequest
+	3,  // 9: daedalus.Daedalus.Done:input_type -> daedalus.DoneRequest
+	5,  // 10: daedalus.Daedalus.Play:input_type -> daedalus.PlayRequest
+	9,  // 11: daedalus.Daedalus.Awake:output_type -> daedalus.Reply
+	9,  // 12: daedalus.Daedalus.Move:output_type -> daedalus.Reply
+	9,  // 13: daedalus.Daedalus.Look:output_type -> daedalus.Reply
+	4,  // 14: daedalus.Daedalus.Done:output_type -> daedalus.DoneReply
+	9,  // 15: daedalus.Daedalus.Play:output_type -> daedalus.Reply
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_daedalus_proto_init() }
+func file_daedalus_proto_init() {
+	if File_daedalus_proto != nil {
+		return
+	}
+	file_daedalus_proto_msgTypes[5].OneofWrappers = []any{
+		(*PlayRequest_Awake)(nil),
+		(*PlayRequest_Move)(nil),
+		(*PlayRequest_Look)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_daedalus_proto_rawDesc), len(file_daedalus_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_daedalus_proto_goTypes,
+		DependencyIndexes: file_daedalus_proto_depIdxs,
+		MessageInfos:      file_daedalus_proto_msgTypes,
+	}.Build()
+	File_daedalus_proto = out.File
+	file_daedalus_proto_goTypes = nil
+	file_daedalus_proto_depIdxs = nil
+}
@@ -0,0 +1,286 @@
+// By Kelvin Yong for Go Challenge 6
+//
+// The Daedalus API over gRPC, next to the HTTP one. It serves mazes with
+// square rooms, and its replies carry the same fields as mazelib.Reply.
+//
+// daedalus.pb.go and daedalus_grpc.pb.go are generated from this file,
+// regenerate them with go generate, see generate.go.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: daedalus.proto
+
+package daedaluspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Daedalus_Awake_FullMethodName = "/daedalus.Daedalus/Awake"
+	Daedalus_Move_FullMethodName  = "/daedalus.Daedalus/Move"
+	Daedalus_Look_FullMethodName  = "/daedalus.Daedalus/Look"
+	Daedalus_Done_FullMethodName  = "/daedalus.Daedalus/Done"
+	Daedalus_Play_FullMethodName  = "/daedalus.Daedalus/Play"
+)
+
+// DaedalusClient is the client API for Daedalus service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DaedalusClient interface {
+	// Awake creates a new maze and places Icarus in it
+	Awake(ctx context.Context, in *AwakeRequest, opts ...grpc.CallOption) (*Reply, error)
+	// Move moves Icarus one step: up, down, left, right, ascend or descend
+	Move(ctx context.Context, in *MoveRequest, opts ...grpc.CallOption) (*Reply, error)
+	// Look looks down the corridors from Icarus's room
+	Look(ctx context.Context, in *LookRequest, opts ...grpc.CallOption) (*Reply, error)
+	// Done ends the session, and Daedalus prints the results and exits
+	Done(ctx context.Context, in *DoneRequest, opts ...grpc.CallOption) (*DoneReply, error)
+	// Play answers each request on the stream with a reply, in order
+	Play(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PlayRequest, Reply], error)
+}
+
+type daedalusClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDaedalusClient(cc grpc.ClientConnInterface) DaedalusClient {
+	return &daedalusClient{cc}
+}
+
+func (c *daedalusClient) Awake(ctx context.Context, in *AwakeRequest, opts ...grpc.CallOption) (*Reply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reply)
+	err := c.cc.Invoke(ctx, Daedalus_Awake_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daedalusClient) Move(ctx context.Context, in *MoveRequest, opts ...grpc.CallOption) (*Reply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reply)
+	err := c.cc.Invoke(ctx, Daedalus_Move_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daedalusClient) Look(ctx context.Context, in *LookRequest, opts ...grpc.CallOption) (*Reply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reply)
+	err := c.cc.Invoke(ctx, Daedalus_Look_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daedalusClient) Done(ctx context.Context, in *DoneRequest, opts ...grpc.CallOption) (*DoneReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DoneReply)
+	err := c.cc.Invoke(ctx, Daedalus_Done_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daedalusClient) Play(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PlayRequest, Reply], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Daedalus_ServiceDesc.Streams[0], Daedalus_Play_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PlayRequest, Reply]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daedalus_PlayClient = grpc.BidiStreamingClient[PlayRequest, Reply]
+
+// DaedalusServer is the server API for Daedalus service.
+// All implementations must embed UnimplementedDaedalusServer
+// for forward compatibility.
+type DaedalusServer interface {
+	// Awake creates a new maze and places Icarus in it
+	Awake(context.Context, *AwakeRequest) (*Reply, error)
+	// Move moves Icarus one step: up, down, left, right, ascend or descend
+	Move(context.Context, *MoveRequest) (*Reply, error)
+	// Look looks down the corridors from Icarus's room
+	Look(context.Context, *LookRequest) (*Reply, error)
+	// Done ends the session, and Daedalus prints the results and exits
+	Done(context.Context, *DoneRequest) (*DoneReply, error)
+	// Play answers each request on the stream with a reply, in order
+	Play(grpc.BidiStreamingServer[PlayRequest, Reply]) error
+	mustEmbedUnimplementedDaedalusServer()
+}
+
+// UnimplementedDaedalusServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDaedalusServer struct{}
+
+func (UnimplementedDaedalusServer) Awake(context.Context, *AwakeRequest) (*Reply, error) {
+	return nil, status.Error(codes.Unimplemented, "method Awake not implemented")
+}
+func (UnimplementedDaedalusServer) Move(context.Context, *MoveRequest) (*Reply, error) {
+	return nil, status.Error(codes.Unimplemented, "method Move not implemented")
+}
+func (UnimplementedDaedalusServer) Look(context.Context, *LookRequest) (*Reply, error) {
+	return nil, status.Error(codes.Unimplemented, "method Look not implemented")
+}
+func (UnimplementedDaedalusServer) Done(context.Context, *DoneRequest) (*DoneReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method Done not implemented")
+}
+func (UnimplementedDaedalusServer) Play(grpc.BidiStreamingServer[PlayRequest, Reply]) error {
+	return status.Error(codes.Unimplemented, "method Play not implemented")
+}
+func (UnimplementedDaedalusServer) mustEmbedUnimplementedDaedalusServer() {}
+func (UnimplementedDaedalusServer) testEmbeddedByValue()                  {}
+
+// UnsafeDaedalusServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DaedalusServer will
+// result in compilation errors.
+type UnsafeDaedalusServer interface {
+	mustEmbedUnimplementedDaedalusServer()
+}
+
+func RegisterDaedalusServer(s grpc.ServiceRegistrar, srv DaedalusServer) {
+	// If the following call panics, it indicates UnimplementedDaedalusServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Daedalus_ServiceDesc, srv)
+}
+
+func _Daedalus_Awake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AwakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaedalusServer).Awake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daedalus_Awake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaedalusServer).Awake(ctx, req.(*AwakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daedalus_Move_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaedalusServer).Move(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daedalus_Move_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaedalusServer).Move(ctx, req.(*MoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daedalus_Look_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaedalusServer).Look(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daedalus_Look_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaedalusServer).Look(ctx, req.(*LookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daedalus_Done_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaedalusServer).Done(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Daedalus_Done_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaedalusServer).Done(ctx, req.(*DoneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Daedalus_Play_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DaedalusServer).Play(&grpc.GenericServerStream[PlayRequest, Reply]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Daedalus_PlayServer = grpc.BidiStreamingServer[PlayRequest, Reply]
+
+// Daedalus_ServiceDesc is the grpc.ServiceDesc for Daedalus service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Daedalus_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "daedalus.Daedalus",
+	HandlerType: (*DaedalusServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Awake",
+			Handler:    _Daedalus_Awake_Handler,
+		},
+		{
+			MethodName: "Move",
+			Handler:    _Daedalus_Move_Handler,
+		},
+		{
+			MethodName: "Look",
+			Handler:    _Daedalus_Look_Handler,
+		},
+		{
+			MethodName: "Done",
+			Handler:    _Daedalus_Done_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Play",
+			Handler:       _Daedalus_Play_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "daedalus.proto",
+}
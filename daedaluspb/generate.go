@@ -0,0 +1,8 @@
+// By Kelvin Yong for Go Challenge 6
+
+package daedaluspb
+
+// daedalus.pb.go and daedalus_grpc.pb.go are generated from daedalus.proto,
+// never edit them by hand. Regenerate them with go generate, which needs
+// protoc, protoc-gen-go v1.36.10 and protoc-gen-go-grpc v1.6.2 on the PATH.
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative daedalus.proto
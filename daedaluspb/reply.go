@@ -0,0 +1,79 @@
+// By Kelvin Yong for Go Challenge 6
+
+package daedaluspb
+
+import (
+	"bitbucket.org/kelvinyong/gc6/mazelib"
+)
+
+// FromReply converts a reply of Daedalus to its gRPC message
+func FromReply(r mazelib.Reply) *Reply {
+	s := r.Survey
+	rep := &Reply{
+		Survey: &Survey{
+			Top: s.Top, Right: s.Right, Bottom: s.Bottom, Left: s.Left, Up: s.Up, Down: s.Down,
+			TopCost: int32(s.TopCost), RightCost: int32(s.RightCost),
+			BottomCost: int32(s.BottomCost), LeftCost: int32(s.LeftCost),
+			TopLock: s.TopLock, RightLock: s.RightLock, BottomLock: s.BottomLock, LeftLock: s.LeftLock,
+		},
+		Teleported: r.Teleported,
+		Shifted:    r.Shifted,
+		Keys:       r.Keys,
+		Remaining:  int32(r.Remaining),
+		PickedUp:   r.PickedUp,
+		Minotaur:   r.Minotaur,
+		Caught:     r.Caught,
+		Code:       r.Code,
+		Hint:       r.Hint,
+		Width:      int32(r.Width),
+		Height:     int32(r.Height),
+		Victory:    r.Victory,
+		Message:    r.Message,
+		Error:      r.Error,
+		Modes:      r.Modes,
+	}
+	if r.Sight != nil {
+		rep.Sight = &Sight{Top: int32(r.Sight.Top), Right: int32(r.Sight.Right), Bottom: int32(r.Sight.Bottom),
+			Left: int32(r.Sight.Left), Treasure: r.Sight.Treasure, Distance: int32(r.Sight.Distance)}
+	}
+	if r.Position != nil {
+		rep.Position = &Coordinate{X: int32(r.Position.X), Y: int32(r.Position.Y), Z: int32(r.Position.Z)}
+	}
+	return rep
+}
+
+// ToReply converts the gRPC message back to a reply of Daedalus
+func (rep *Reply) ToReply() mazelib.Reply {
+	s := rep.GetSurvey()
+	r := mazelib.Reply{
+		Survey: mazelib.Survey{
+			Top: s.GetTop(), Right: s.GetRight(), Bottom: s.GetBottom(), Left: s.GetLeft(), Up: s.GetUp(), Down: s.GetDown(),
+			TopCost: int(s.GetTopCost()), RightCost: int(s.GetRightCost()),
+			BottomCost: int(s.GetBottomCost()), LeftCost: int(s.GetLeftCost()),
+			TopLock: s.GetTopLock(), RightLock: s.GetRightLock(), BottomLock: s.GetBottomLock(), LeftLock: s.GetLeftLock(),
+		},
+		Teleported: rep.GetTeleported(),
+		Shifted:    rep.GetShifted(),
+		Keys:       rep.GetKeys(),
+		Remaining:  int(rep.GetRemaining()),
+		PickedUp:   rep.GetPickedUp(),
+		Minotaur:   rep.GetMinotaur(),
+		Caught:     rep.GetCaught(),
+		Code:       rep.GetCode(),
+		Hint:       rep.GetHint(),
+		Width:      int(rep.GetWidth()),
+		Height:     int(rep.GetHeight()),
+		Victory:    rep.GetVictory(),
+		Message:    rep.GetMessage(),
+		Error:      rep.GetError(),
+		Modes:      rep.GetModes(),
+	}
+	if sight := rep.GetSight(); sight != nil {
+		r.Sight = &mazelib.Sight{Top: int(sight.Top), Right: int(sight.Right), Bottom: int(sight.Bottom),
+			Left: int(sight.Left), Treasure: sight.Treasure, Distance: int(sight.Distance)}
+	}
+	if p := rep.GetPosition(); p != nil {
+		r.Position = &mazelib.Coordinate{X: int(p.X), Y: int(p.Y), Z: int(p.Z)}
+	}
+	return r
+}
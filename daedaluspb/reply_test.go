@@ -0,0 +1,47 @@
+// By Kelvin Yong for Go Challenge 6
+
+package daedaluspb
+
+import (
+	"reflect"
+	"testing"
+
+	"bitbucket.org/kelvinyong/gc6/mazelib"
+)
+
+func TestReplyRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		r    mazelib.Reply
+	}{
+		{"empty", mazelib.Reply{}},
+		{"survey", mazelib.Reply{Survey: mazelib.Survey{
+			Top: true, Left: true, Up: true,
+			RightCost: 3, BottomCost: 5,
+			RightLock: "red",
+		}}},
+		{"everything", mazelib.Reply{
+			Survey:     mazelib.Survey{Bottom: true, Down: true, TopCost: 1, LeftLock: "blue"},
+			Teleported: true,
+			Shifted:    true,
+			Keys:       []string{"red", "green"},
+			Remaining:  2,
+			PickedUp:   true,
+			Minotaur:   "left",
+			Hint:       "warm",
+			Sight:      &mazelib.Sight{Top: 1, Right: 2, Bottom: 3, Left: 4, Treasure: "down", Distance: 3},
+			Width:      15,
+			Height:     10,
+			Position:   &mazelib.Coordinate{X: 4, Y: 7, Z: 1},
+			Modes:      []string{mazelib.ModeLook, mazelib.ModeTorus},
+		}},
+		{"victory", mazelib.Reply{Victory: true, Message: "Victory achieved in 9 steps \n"}},
+		{"error", mazelib.Reply{Error: true, Caught: true, Code: mazelib.CodeCaught, Message: mazelib.ErrCaught.Error()}},
+	}
+
+	for _, tt := range tests {
+		if got := FromReply(tt.r).ToReply(); !reflect.DeepEqual(got, tt.r) {
+			t.Errorf("%s: round trip gave %+v, want %+v", tt.name, got, tt.r)
+		}
+	}
+}
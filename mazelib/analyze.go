@@ -0,0 +1,231 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Metrics describes the structure of a maze.
+// Passages are only counted if they are open from both sides.
+type Metrics struct {
+	// Rooms is the number of rooms that are not rock
+	Rooms    int
+	DeadEnds int
+
+	// Junctions counts rooms with 3 or more passages, keyed by the number of passages
+	Junctions map[int]int
+
+	// Corridors holds the length of each run of rooms with exactly 2 passages
+	Corridors []int
+
+	// SolutionLength is the number of steps on the shortest path from
+	// Icarus to the treasure, or -1 if there is no path
+	SolutionLength int
+
+	// Turns is the number of changes of direction on the shortest path
+	Turns int
+
+	// RiverFactor is the fraction of rooms that are not dead ends.
+	// Mazes with a high river factor have long winding passages.
+	RiverFactor float64
+
+	// Loops is the number of passages that can be removed without
+	// disconnecting any room. It is 0 for a perfect maze.
+	Loops int
+}
+
+// MeanCorridor returns the average length of the corridors
+func (mt Metrics) MeanCorridor() float64 {
+	if len(mt.Corridors) == 0 {
+		return 0
+	}
+	total := 0
+	for _, c := range mt.Corridors {
+		total += c
+	}
+	return float64(total) / float64(len(mt.Corridors))
+}
+
+// LongestCorridor returns the length of the longest corridor
+func (mt Metrics) LongestCorridor() int {
+	longest := 0
+	for _, c := range mt.Corridors {
+		if c > longest {
+			longest = c
+		}
+	}
+	return longest
+}
+
+// String gives a human readable summary of the metrics
+func (mt Metrics) String() string {
+	degrees := make([]int, 0, len(mt.Junctions))
+	for d := range mt.Junctions {
+		degrees = append(degrees, d)
+	}
+	sort.Ints(degrees)
+	junctions := make([]string, 0, len(degrees))
+	for _, d := range degrees {
+		junctions = append(junctions, fmt.Sprintf("%d-way: %d", d, mt.Junctions[d]))
+	}
+
+	return fmt.Sprintf("rooms: %d, dead ends: %d, junctions: [%s]\n"+
+		"corridors: %d, mean length: %.1f, longest: %d\n"+
+		"solution: %d steps, %d turns, river: %.2f, loops: %d",
+		mt.Rooms, mt.DeadEnds, strings.Join(junctions, ", "),
+		len(mt.Corridors), mt.MeanCorridor(), mt.LongestCorridor(),
+		mt.SolutionLength, mt.Turns, mt.RiverFactor, mt.Loops)
+}
+
+// Analyze computes the structural metrics of a maze. It fails on a maze
+// without rooms or without a treasure, which have nothing to measure.
+func Analyze(m MazeI) (Metrics, error) {
+	w, h := m.Width(), m.Height()
+	if w <= 0 || h <= 0 {
+		return Metrics{}, errors.New("maze has no rooms to analyze")
+	}
+	torus := isTorus(m)
+	mt := Metrics{Rooms: w * h, Junctions: make(map[int]int), SolutionLength: -1}
+
+	surveys := make([][]Survey, h)
+	rock := make(map[Coordinate]bool)
+	var treasure Coordinate
+	hasTreasure := false
+	for y := 0; y < h; y++ {
+		surveys[y] = make([]Survey, w)
+		for x := 0; x < w; x++ {
+			surveys[y][x], _ = m.Discover(x, y)
+			r, err := m.GetRoom(x, y)
+			if err != nil {
+				continue
+			}
+			if r.Treasure {
+				treasure = Coordinate{X: x, Y: y}
+				hasTreasure = true
+			}
+			if r.Excluded {
+				rock[Coordinate{X: x, Y: y}] = true
+			}
+		}
+	}
+	mt.Rooms -= len(rock)
+	if mt.Rooms == 0 {
+		return Metrics{}, errors.New("maze is all rock, it has no rooms to analyze")
+	}
+	if !hasTreasure {
+		return Metrics{}, errors.New("maze has no treasure to measure the solution to")
+	}
+
+	// passages from each room that are open from both sides
+	open := func(c Coordinate) []int {
+		dirs := make([]int, 0, 4)
+		for _, dir := range []int{N, S, E, W} {
+			if wallFacing(surveys[c.Y][c.X], dir) {
+				continue
+			}
+			next, ok := neighbour(c, dir, w, h, torus)
+			if !ok || wallFacing(surveys[next.Y][next.X], Opposite[dir]) {
+				continue
+			}
+			dirs = append(dirs, dir)
+		}
+		return dirs
+	}
+
+	degree := make(map[Coordinate]int, w*h)
+	passages := 0
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			d := len(open(Coordinate{X: x, Y: y}))
+			degree[Coordinate{X: x, Y: y}] = d
+			passages += d
+			switch {
+			case d == 1:
+				mt.DeadEnds++
+			case d >= 3:
+				mt.Junctions[d]++
+			}
+		}
+	}
+	passages /= 2
+	mt.RiverFactor = 1 - float64(mt.DeadEnds)/float64(mt.Rooms)
+
+	// corridors are connected runs of rooms with 2 passages
+	seen := make(map[Coordinate]bool, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := Coordinate{X: x, Y: y}
+			if degree[c] != 2 || seen[c] {
+				continue
+			}
+			length := 0
+			seen[c] = true
+			queue := []Coordinate{c}
+			for len(queue) > 0 {
+				cur := queue[0]
+				queue = queue[1:]
+				length++
+				for _, dir := range open(cur) {
+					next, _ := neighbour(cur, dir, w, h, torus)
+					if degree[next] == 2 && !seen[next] {
+						seen[next] = true
+						queue = append(queue, next)
+					}
+				}
+			}
+			mt.Corridors = append(mt.Corridors, length)
+		}
+	}
+
+	// loops = passages - rooms + regions
+	regions := 0
+	seen = make(map[Coordinate]bool, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if !seen[Coordinate{X: x, Y: y}] && !rock[Coordinate{X: x, Y: y}] {
+				regions++
+				flood(surveys, Coordinate{X: x, Y: y}, seen, true, torus)
+			}
+		}
+	}
+	mt.Loops = passages - mt.Rooms + regions
+
+	// breadth first search for the shortest path to the treasure
+	sx, sy := m.Icarus()
+	start := Coordinate{X: sx, Y: sy}
+	arrived := map[Coordinate]int{start: 0}
+	parent := make(map[Coordinate]Coordinate, w*h)
+	queue := []Coordinate{start}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if cur == treasure {
+			break
+		}
+		for _, dir := range open(cur) {
+			next, _ := neighbour(cur, dir, w, h, torus)
+			if _, ok := arrived[next]; ok {
+				continue
+			}
+			arrived[next] = dir
+			parent[next] = cur
+			queue = append(queue, next)
+		}
+	}
+
+	if _, ok := arrived[treasure]; ok {
+		mt.SolutionLength = 0
+		for cur := treasure; cur != start; cur = parent[cur] {
+			mt.SolutionLength++
+			if prev := parent[cur]; prev != start && arrived[prev] != arrived[cur] {
+				mt.Turns++
+			}
+		}
+	}
+
+	return mt, nil
+}
@@ -0,0 +1,66 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name     string
+		maze     func() MazeI
+		ok       bool
+		deadEnds int
+		solution int
+		turns    int
+		loops    int
+	}{
+		{"corridor", func() MazeI { return corridor() }, true, 2, 2, 0, 0},
+		{"serpentine", func() MazeI {
+			m := NewFullGrid(3, 3)
+			serpentine(m)
+			m.SetStartPoint(0, 0)
+			m.SetTreasure(2, 2)
+			return m
+		}, true, 2, 8, 4, 0},
+		{"loop", func() MazeI {
+			m := NewEmptyGrid(2, 2)
+			m.SetStartPoint(0, 0)
+			m.SetTreasure(1, 1)
+			return m
+		}, true, 0, 2, 1, 1},
+		{"walled off treasure", func() MazeI {
+			m := corridor()
+			m.AddWall(1, 0, E)
+			return m
+		}, true, 2, -1, 0, 0},
+		{"no treasure", func() MazeI {
+			m := NewEmptyGrid(2, 2)
+			m.SetStartPoint(0, 0)
+			return m
+		}, false, 0, 0, 0, 0},
+		{"all rock", func() MazeI {
+			m := NewFullGrid(2, 1)
+			m.Exclude(0, 0)
+			m.Exclude(1, 0)
+			return m
+		}, false, 0, 0, 0, 0},
+		{"no rooms", func() MazeI { return NewCompactMaze(0, 0) }, false, 0, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		mt, err := Analyze(tt.maze())
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: error %v, want ok %t", tt.name, err, tt.ok)
+			continue
+		}
+		if !tt.ok {
+			continue
+		}
+		if mt.DeadEnds != tt.deadEnds || mt.SolutionLength != tt.solution || mt.Turns != tt.turns || mt.Loops != tt.loops {
+			t.Errorf("%s: %d dead ends, solution %d with %d turns, %d loops, want %d, %d, %d, %d",
+				tt.name, mt.DeadEnds, mt.SolutionLength, mt.Turns, mt.Loops, tt.deadEnds, tt.solution, tt.turns, tt.loops)
+		}
+	}
+}
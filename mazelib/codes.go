@@ -0,0 +1,68 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"errors"
+)
+
+// Errors a move can fail with, besides ErrCaught. A Reply tells them
+// apart by its Code, see ErrorCode.
+var (
+	ErrWall             = errors.New("Can't walk through walls")
+	ErrLocked           = errors.New("the door is locked, Icarus needs its key")
+	ErrOutOfBounds      = errors.New("room outside of maze boundaries")
+	ErrInvalidDirection = errors.New("invalid direction")
+	ErrSessionEnded     = errors.New("the maze is over, Icarus has to wake up in a new one")
+	ErrStepLimit        = errors.New("Icarus ran out of steps")
+)
+
+// Codes of the errors in a Reply
+const (
+	CodeWall             = "wall"
+	CodeLocked           = "locked"
+	CodeOutOfBounds      = "out-of-bounds"
+	CodeInvalidDirection = "invalid-direction"
+	CodeSessionEnded     = "session-ended"
+	CodeStepLimit        = "step-limit"
+	CodeCaught           = "caught"
+)
+
+// codes maps the errors to their codes. Moving once the treasure is
+// found fails with ErrVictory, as the maze is over.
+var codes = map[error]string{
+	ErrWall:             CodeWall,
+	ErrLocked:           CodeLocked,
+	ErrOutOfBounds:      CodeOutOfBounds,
+	ErrInvalidDirection: CodeInvalidDirection,
+	ErrSessionEnded:     CodeSessionEnded,
+	ErrVictory:          CodeSessionEnded,
+	ErrStepLimit:        CodeStepLimit,
+	ErrCaught:           CodeCaught,
+}
+
+// ErrorCode returns the code of the error for a Reply, or "" if it has none
+func ErrorCode(err error) string {
+	return codes[err]
+}
+
+// CodeError returns the error with the code of a Reply, or nil if the
+// code is unknown
+func CodeError(code string) error {
+	if code == CodeSessionEnded {
+		return ErrSessionEnded
+	}
+	for err, c := range codes {
+		if c == code {
+			return err
+		}
+	}
+	return nil
+}
+
+// Over returns if the error ends the maze, either way. The error may
+// wrap one of the errors of mazelib, as the errors of a client do.
+func Over(err error) bool {
+	return errors.Is(err, ErrVictory) || errors.Is(err, ErrCaught) ||
+		errors.Is(err, ErrSessionEnded) || errors.Is(err, ErrStepLimit)
+}
@@ -0,0 +1,48 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorCodes(t *testing.T) {
+	tests := []struct {
+		err  error
+		code string
+		// the error CodeError gives back for the code
+		back error
+		over bool
+	}{
+		{ErrWall, CodeWall, ErrWall, false},
+		{ErrLocked, CodeLocked, ErrLocked, false},
+		{ErrOutOfBounds, CodeOutOfBounds, ErrOutOfBounds, false},
+		{ErrInvalidDirection, CodeInvalidDirection, ErrInvalidDirection, false},
+		{ErrSessionEnded, CodeSessionEnded, ErrSessionEnded, true},
+		{ErrVictory, CodeSessionEnded, ErrSessionEnded, true},
+		{ErrStepLimit, CodeStepLimit, ErrStepLimit, true},
+		{ErrCaught, CodeCaught, ErrCaught, true},
+		{errors.New("something else"), "", nil, false},
+		{nil, "", nil, false},
+	}
+
+	for _, tt := range tests {
+		if code := ErrorCode(tt.err); code != tt.code {
+			t.Errorf("ErrorCode(%v) is %q, want %q", tt.err, code, tt.code)
+		}
+		if back := CodeError(tt.code); back != tt.back {
+			t.Errorf("CodeError(%q) is %v, want %v", tt.code, back, tt.back)
+		}
+		if over := Over(tt.err); over != tt.over {
+			t.Errorf("Over(%v) is %t, want %t", tt.err, over, tt.over)
+		}
+		// as a client wraps them
+		if tt.err != nil {
+			if over := Over(fmt.Errorf("daedalus: %w", tt.err)); over != tt.over {
+				t.Errorf("Over of wrapped %v is %t, want %t", tt.err, over, tt.over)
+			}
+		}
+	}
+}
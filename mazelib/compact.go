@@ -0,0 +1,295 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"errors"
+)
+
+// bitset is a fixed size set of bits
+type bitset []uint64
+
+func newBitset(n int) bitset { return make(bitset, (n+63)/64) }
+
+func (b bitset) get(i int) bool { return b[i/64]&(1<<uint(i%64)) != 0 }
+func (b bitset) set(i int)      { b[i/64] |= 1 << uint(i%64) }
+func (b bitset) clear(i int)    { b[i/64] &^= 1 << uint(i%64) }
+
+// CompactMaze is a maze for huge dimensions. Instead of a Room per
+// location, every wall is a single bit shared by the two rooms on either
+// side of it, so a wall can never be one way.
+// Horizontal walls are stored row by row, including the perimeter above
+// the first row and below the last row. Vertical walls are stored the same
+// way, including the perimeter left of the first column and right of the
+// last column. On a torus the walls on opposite edges are the same bit.
+type CompactMaze struct {
+	width, height int
+	wrap          bool
+	horizontal    bitset // (height+1) * width walls
+	vertical      bitset // height * (width+1) walls
+	rock          bitset // rooms outside of the shape of the maze, nil if there are none
+
+	start, end, icarus Coordinate
+	hasStart, hasEnd   bool
+	StepsTaken         int
+}
+
+// NewCompactMaze creates a maze without any walls but the perimeter
+// Good starting point for additive algorithms
+func NewCompactMaze(width, height int) *CompactMaze {
+	m := &CompactMaze{
+		width:      width,
+		height:     height,
+		horizontal: newBitset((height + 1) * width),
+		vertical:   newBitset(height * (width + 1)),
+	}
+
+	for x := 0; x < width; x++ {
+		m.AddWall(x, 0, N)
+		m.AddWall(x, height-1, S)
+	}
+	for y := 0; y < height; y++ {
+		m.AddWall(0, y, W)
+		m.AddWall(width-1, y, E)
+	}
+	return m
+}
+
+// NewFullCompactMaze creates a maze with all walls
+// Good starting point for subtractive algorithms
+func NewFullCompactMaze(width, height int) *CompactMaze {
+	m := NewCompactMaze(width, height)
+	for i := range m.horizontal {
+		m.horizontal[i] = ^uint64(0)
+	}
+	for i := range m.vertical {
+		m.vertical[i] = ^uint64(0)
+	}
+	return m
+}
+
+// NewCompactTorus creates a torus without any walls.
+// Walking off one edge brings Icarus in on the opposite edge.
+func NewCompactTorus(width, height int) *CompactMaze {
+	m := NewCompactMaze(width, height)
+	m.wrap = true
+	for x := 0; x < width; x++ {
+		m.RmWall(x, 0, N)
+	}
+	for y := 0; y < height; y++ {
+		m.RmWall(0, y, W)
+	}
+	return m
+}
+
+// NewFullCompactTorus creates a torus with all walls
+func NewFullCompactTorus(width, height int) *CompactMaze {
+	m := NewFullCompactMaze(width, height)
+	m.wrap = true
+	return m
+}
+
+// Wraps returns if the maze is a torus
+func (m *CompactMaze) Wraps() bool { return m.wrap }
+
+// wallIndex returns the bitset and position of the wall of room (x, y)
+// facing the given direction
+func (m *CompactMaze) wallIndex(x, y, dir int) (bitset, int) {
+	switch dir {
+	case N:
+		return m.horizontal, y*m.width + x
+	case S:
+		if m.wrap && y == m.height-1 {
+			return m.horizontal, x
+		}
+		return m.horizontal, (y+1)*m.width + x
+	case W:
+		return m.vertical, y*(m.width+1) + x
+	case E:
+		if m.wrap && x == m.width-1 {
+			return m.vertical, y * (m.width + 1)
+		}
+		return m.vertical, y*(m.width+1) + x + 1
+	}
+	return nil, -1
+}
+
+func (m *CompactMaze) inside(x, y int) bool {
+	return x >= 0 && y >= 0 && x < m.width && y < m.height
+}
+
+// AddWall adds the wall of room (x, y) facing the given direction.
+// The neighbouring room shares the same wall.
+func (m *CompactMaze) AddWall(x, y, dir int) {
+	if b, i := m.wallIndex(x, y, dir); b != nil && m.inside(x, y) {
+		b.set(i)
+	}
+}
+
+// RmWall removes the wall of room (x, y) facing the given direction.
+// The neighbouring room shares the same wall.
+func (m *CompactMaze) RmWall(x, y, dir int) {
+	if b, i := m.wallIndex(x, y, dir); b != nil && m.inside(x, y) {
+		b.clear(i)
+	}
+}
+
+// Exclude turns room (x, y) into solid rock, walled off from its neighbours
+func (m *CompactMaze) Exclude(x, y int) {
+	if !m.inside(x, y) {
+		return
+	}
+	if m.rock == nil {
+		m.rock = newBitset(m.width * m.height)
+	}
+	m.rock.set(y*m.width + x)
+	for _, dir := range []int{N, S, E, W} {
+		m.AddWall(x, y, dir)
+	}
+}
+
+// Excluded returns if room (x, y) is solid rock
+func (m *CompactMaze) Excluded(x, y int) bool {
+	return m.rock != nil && m.inside(x, y) && m.rock.get(y*m.width+x)
+}
+
+// GetRoom returns a Room built from the walls around (x, y).
+// The Room is a copy, changing it does not change the maze.
+func (m *CompactMaze) GetRoom(x, y int) (*Room, error) {
+	s, err := m.Discover(x, y)
+	if err != nil {
+		return &Room{}, err
+	}
+	c := Coordinate{X: x, Y: y}
+	return &Room{
+		Treasure: m.hasEnd && c == m.end,
+		Start:    m.hasStart && c == m.start,
+		Excluded: m.Excluded(x, y),
+		Walls:    s,
+	}, nil
+}
+
+// Width returns width of the maze
+func (m *CompactMaze) Width() int { return m.width }
+
+// Height returns height of the maze
+func (m *CompactMaze) Height() int { return m.height }
+
+// Steps returns the number of steps Icarus has taken
+func (m *CompactMaze) Steps() int { return m.StepsTaken }
+
+// Cost returns the total cost of Icarus's steps.
+// A compact maze has no terrain, so every step costs 1.
+func (m *CompactMaze) Cost() int { return m.StepsTaken }
+
+// Icarus returns the finder's current position
+func (m *CompactMaze) Icarus() (x, y int) {
+	return m.icarus.X, m.icarus.Y
+}
+
+// SetStartPoint sets the location where Icarus will awake
+func (m *CompactMaze) SetStartPoint(x, y int) error {
+	if !m.inside(x, y) {
+		return ErrOutOfBounds
+	}
+	if m.hasEnd && m.end == (Coordinate{X: x, Y: y}) {
+		return errors.New("can't start in the treasure")
+	}
+	if m.Excluded(x, y) {
+		return errors.New("can't start inside rock")
+	}
+
+	m.start = Coordinate{X: x, Y: y}
+	m.hasStart = true
+	m.icarus = m.start
+	return nil
+}
+
+// SetTreasure sets the location of the treasure for a given maze
+func (m *CompactMaze) SetTreasure(x, y int) error {
+	if !m.inside(x, y) {
+		return ErrOutOfBounds
+	}
+	if m.hasStart && m.start == (Coordinate{X: x, Y: y}) {
+		return errors.New("can't have the treasure at the start")
+	}
+	if m.Excluded(x, y) {
+		return errors.New("can't have the treasure inside rock")
+	}
+
+	m.end = Coordinate{X: x, Y: y}
+	m.hasEnd = true
+	return nil
+}
+
+// LookAround Given Icarus's current location, Discover that room
+// Will return ErrVictory if Icarus is at the treasure.
+func (m *CompactMaze) LookAround() (Survey, error) {
+	if m.hasEnd && m.end == m.icarus {
+		return Survey{}, ErrVictory
+	}
+	return m.Discover(m.icarus.X, m.icarus.Y)
+}
+
+// Discover Given two points, survey the room.
+// Will return error if two points are outside of the maze
+func (m *CompactMaze) Discover(x, y int) (Survey, error) {
+	if !m.inside(x, y) {
+		return Survey{}, ErrOutOfBounds
+	}
+
+	var s Survey
+	b, i := m.wallIndex(x, y, N)
+	s.Top = b.get(i)
+	b, i = m.wallIndex(x, y, S)
+	s.Bottom = b.get(i)
+	b, i = m.wallIndex(x, y, W)
+	s.Left = b.get(i)
+	b, i = m.wallIndex(x, y, E)
+	s.Right = b.get(i)
+
+	// A single level has a floor and ceiling everywhere
+	s.Up, s.Down = true, true
+	return s, nil
+}
+
+// move Moves Icarus's position one step in the given direction
+// Will not permit moving through walls or out of the maze
+func (m *CompactMaze) move(dir int) error {
+	if _, e := m.LookAround(); e != nil {
+		return e
+	}
+	if b, i := m.wallIndex(m.icarus.X, m.icarus.Y, dir); b == nil || b.get(i) {
+		return ErrWall
+	}
+
+	c, _ := neighbour(m.icarus, dir, m.width, m.height, m.wrap)
+	x, y := c.X, c.Y
+	if !m.inside(x, y) {
+		return ErrOutOfBounds
+	}
+
+	m.icarus = Coordinate{X: x, Y: y}
+	m.StepsTaken++
+	return nil
+}
+
+// MoveLeft Moves Icarus's position left one step
+func (m *CompactMaze) MoveLeft() error { return m.move(W) }
+
+// MoveRight Moves Icarus's position right one step
+func (m *CompactMaze) MoveRight() error { return m.move(E) }
+
+// MoveUp Moves Icarus's position up one step
+func (m *CompactMaze) MoveUp() error { return m.move(N) }
+
+// MoveDown Moves Icarus's position down one step
+func (m *CompactMaze) MoveDown() error { return m.move(S) }
+
+// MoveAbove Moves Icarus's position to the level above
+// There is only one level, so this is never permitted
+func (m *CompactMaze) MoveAbove() error { return m.move(U) }
+
+// MoveBelow Moves Icarus's position to the level below
+// There is only one level, so this is never permitted
+func (m *CompactMaze) MoveBelow() error { return m.move(D) }
@@ -0,0 +1,77 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+func TestCompactSharedWalls(t *testing.T) {
+	tests := []struct {
+		name  string
+		torus bool
+		x, y  int
+		dir   int
+		// the room on the other side of the wall
+		nx, ny int
+	}{
+		{"east", false, 1, 1, E, 2, 1},
+		{"south", false, 1, 1, S, 1, 2},
+		{"west", false, 1, 1, W, 0, 1},
+		{"north", false, 1, 1, N, 1, 0},
+		{"east edge of a torus", true, 2, 1, E, 0, 1},
+		{"south edge of a torus", true, 1, 2, S, 1, 0},
+		{"west edge of a torus", true, 0, 1, W, 2, 1},
+		{"north edge of a torus", true, 1, 0, N, 1, 2},
+	}
+
+	for _, tt := range tests {
+		m := NewFullCompactMaze(3, 3)
+		if tt.torus {
+			m = NewFullCompactTorus(3, 3)
+		}
+
+		m.RmWall(tt.x, tt.y, tt.dir)
+		s, _ := m.Discover(tt.x, tt.y)
+		n, _ := m.Discover(tt.nx, tt.ny)
+		if wallFacing(s, tt.dir) || wallFacing(n, Opposite[tt.dir]) {
+			t.Errorf("%s: removed wall still seen from (%d, %d) or (%d, %d)", tt.name, tt.x, tt.y, tt.nx, tt.ny)
+		}
+
+		m.AddWall(tt.nx, tt.ny, Opposite[tt.dir])
+		s, _ = m.Discover(tt.x, tt.y)
+		n, _ = m.Discover(tt.nx, tt.ny)
+		if !wallFacing(s, tt.dir) || !wallFacing(n, Opposite[tt.dir]) {
+			t.Errorf("%s: added wall missing from (%d, %d) or (%d, %d)", tt.name, tt.x, tt.y, tt.nx, tt.ny)
+		}
+	}
+}
+
+func TestCompactPerimeter(t *testing.T) {
+	tests := []struct {
+		name   string
+		maze   *CompactMaze
+		closed bool
+	}{
+		{"empty maze", NewCompactMaze(4, 3), true},
+		{"full maze", NewFullCompactMaze(4, 3), true},
+		{"empty torus", NewCompactTorus(4, 3), false},
+	}
+
+	for _, tt := range tests {
+		for x := 0; x < 4; x++ {
+			top, _ := tt.maze.Discover(x, 0)
+			bottom, _ := tt.maze.Discover(x, 2)
+			if top.Top != tt.closed || bottom.Bottom != tt.closed {
+				t.Errorf("%s: column %d open at the edge is %t, want %t", tt.name, x, !top.Top, !tt.closed)
+			}
+		}
+		for y := 0; y < 3; y++ {
+			left, _ := tt.maze.Discover(0, y)
+			right, _ := tt.maze.Discover(3, y)
+			if left.Left != tt.closed || right.Right != tt.closed {
+				t.Errorf("%s: row %d open at the edge is %t, want %t", tt.name, y, !left.Left, !tt.closed)
+			}
+		}
+	}
+}
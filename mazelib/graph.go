@@ -0,0 +1,353 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"errors"
+)
+
+// Exit is a labelled way out of a node, leading to another node.
+// Back is the label of the exit that leads back from the other node.
+// Each side of a passage has its own wall.
+type Exit struct {
+	Label string
+	To    int
+	Back  string
+	Wall  bool
+}
+
+// Node is a room of a GraphMaze
+type Node struct {
+	Treasure bool
+	Start    bool
+	Exits    []Exit
+}
+
+// exit returns the exit of the node with the given label, or nil
+func (n *Node) exit(label string) *Exit {
+	for i := range n.Exits {
+		if n.Exits[i].Label == label {
+			return &n.Exits[i]
+		}
+	}
+	return nil
+}
+
+// ExitSurvey Given a location, list the exits without a wall.
+// It works for any layout of rooms.
+type ExitSurvey struct {
+	Exits []string `json:"exits"`
+}
+
+// GraphReply is an ExitSurvey sent to a solver, see MazeReply.
+// Back is the label of the exit to the room Icarus came from.
+type GraphReply struct {
+	Survey ExitSurvey
+	Back   string
+	Err    error
+}
+
+// GraphMaze is a maze of nodes joined by labelled exits. It knows nothing
+// about the layout of the rooms, which is left to the views that build it,
+// such as GraphFromMaze or GraphFromHex.
+type GraphMaze struct {
+	nodes      []Node
+	start      int
+	end        int
+	icarus     int
+	back       string
+	StepsTaken int
+}
+
+// NewGraphMaze creates a maze with the given number of nodes and no exits
+func NewGraphMaze(nodes int) *GraphMaze {
+	return &GraphMaze{nodes: make([]Node, nodes), end: -1}
+}
+
+// Nodes returns the number of nodes of the maze
+func (m *GraphMaze) Nodes() int { return len(m.nodes) }
+
+// Steps returns the number of steps Icarus has taken
+func (m *GraphMaze) Steps() int { return m.StepsTaken }
+
+// Cost returns the total cost of Icarus's steps. Every exit costs 1,
+// stairs included, since the graph knows nothing of the layout.
+func (m *GraphMaze) Cost() int { return m.StepsTaken }
+
+// GetNode returns node n
+func (m *GraphMaze) GetNode(n int) (*Node, error) {
+	if n < 0 || n >= len(m.nodes) {
+		return &Node{}, ErrOutOfBounds
+	}
+	return &m.nodes[n], nil
+}
+
+// AddExit adds an exit to node from, labelled label, leading to node to.
+// back is the label of the exit leading back, which is added separately.
+func (m *GraphMaze) AddExit(from int, label string, to int, back string, wall bool) error {
+	n, err := m.GetNode(from)
+	if err != nil {
+		return err
+	}
+	if _, err := m.GetNode(to); err != nil {
+		return err
+	}
+	if n.exit(label) != nil {
+		return errors.New("exit " + label + " already exists")
+	}
+
+	n.Exits = append(n.Exits, Exit{Label: label, To: to, Back: back, Wall: wall})
+	return nil
+}
+
+// AddWall adds a wall to the exit of node n and to the exit leading back
+func (m *GraphMaze) AddWall(n int, label string) {
+	m.setWall(n, label, true)
+}
+
+// RmWall removes the wall of the exit of node n and of the exit leading back
+func (m *GraphMaze) RmWall(n int, label string) {
+	m.setWall(n, label, false)
+}
+
+func (m *GraphMaze) setWall(n int, label string, wall bool) {
+	node, err := m.GetNode(n)
+	if err != nil {
+		return
+	}
+	e := node.exit(label)
+	if e == nil {
+		return
+	}
+	e.Wall = wall
+	if back := m.nodes[e.To].exit(e.Back); back != nil {
+		back.Wall = wall
+	}
+}
+
+// Icarus returns the node Icarus is in
+func (m *GraphMaze) Icarus() int { return m.icarus }
+
+// Back returns the label of the exit to the room Icarus came from,
+// or "" if he hasn't moved yet
+func (m *GraphMaze) Back() string { return m.back }
+
+// SetStartPoint sets the node where Icarus will awake
+func (m *GraphMaze) SetStartPoint(n int) error {
+	node, err := m.GetNode(n)
+	if err != nil {
+		return err
+	}
+
+	if node.Treasure {
+		return errors.New("can't start in the treasure")
+	}
+
+	node.Start = true
+	m.start = n
+	m.icarus = n
+	m.back = ""
+	return nil
+}
+
+// SetTreasure sets the node of the treasure
+func (m *GraphMaze) SetTreasure(n int) error {
+	node, err := m.GetNode(n)
+	if err != nil {
+		return err
+	}
+
+	if node.Start {
+		return errors.New("can't have the treasure at the start")
+	}
+
+	node.Treasure = true
+	m.end = n
+	return nil
+}
+
+// LookAround Given Icarus's current location, Discover that room
+// Will return ErrVictory if Icarus is at the treasure.
+func (m *GraphMaze) LookAround() (ExitSurvey, error) {
+	if m.end == m.icarus {
+		return ExitSurvey{}, ErrVictory
+	}
+
+	return m.Discover(m.icarus)
+}
+
+// Discover lists the open exits of node n
+func (m *GraphMaze) Discover(n int) (ExitSurvey, error) {
+	node, err := m.GetNode(n)
+	if err != nil {
+		return ExitSurvey{}, err
+	}
+
+	s := ExitSurvey{Exits: make([]string, 0, len(node.Exits))}
+	for _, e := range node.Exits {
+		if !e.Wall {
+			s.Exits = append(s.Exits, e.Label)
+		}
+	}
+	return s, nil
+}
+
+// Move Moves Icarus through the exit with the given label
+// Will not permit moving through walls or through exits that don't exist
+func (m *GraphMaze) Move(label string) error {
+	if _, e := m.LookAround(); e != nil {
+		return e
+	}
+
+	e := m.nodes[m.icarus].exit(label)
+	if e == nil {
+		return ErrInvalidDirection
+	}
+	if e.Wall {
+		return ErrWall
+	}
+
+	m.icarus = e.To
+	m.back = e.Back
+	m.StepsTaken++
+	return nil
+}
+
+// SquareMoves are the labels of the exits of square rooms, named as
+// the directions Icarus moves in on the server
+var SquareMoves = map[int]string{
+	N: "up",
+	S: "down",
+	E: "right",
+	W: "left",
+	U: "ascend",
+	D: "descend",
+}
+
+// GraphFromMaze builds a graph with the same rooms and walls as a maze
+// with a single level. Each room is a node numbered y*width+x, with its
+// exits labelled by SquareMoves. Rock has no exits.
+func GraphFromMaze(m MazeI) *GraphMaze {
+	w, h := m.Width(), m.Height()
+	torus := isTorus(m)
+	g := NewGraphMaze(w * h)
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			r, err := m.GetRoom(x, y)
+			if err != nil || r.Excluded {
+				continue
+			}
+			s, _ := m.Discover(x, y)
+			for _, dir := range []int{N, S, E, W} {
+				next, ok := neighbour(Coordinate{X: x, Y: y}, dir, w, h, torus)
+				if !ok || roomExcluded(m, next.X, next.Y) {
+					continue
+				}
+				g.AddExit(y*w+x, SquareMoves[dir], next.Y*w+next.X, SquareMoves[Opposite[dir]], wallFacing(s, dir))
+			}
+			if r.Treasure {
+				g.SetTreasure(y*w + x)
+			}
+		}
+	}
+
+	ix, iy := m.Icarus()
+	g.SetStartPoint(iy*w + ix)
+	return g
+}
+
+// GraphFromLevels builds a graph with the same rooms, walls and stairs
+// as a maze with several levels. Each room is a node numbered
+// (z*height+y)*width+x, with its exits labelled by SquareMoves.
+func GraphFromLevels(m *LevelMaze) *GraphMaze {
+	w, h, l := m.Width(), m.Height(), m.Levels()
+	index := func(c Coordinate) int { return (c.Z*h+c.Y)*w + c.X }
+	g := NewGraphMaze(w * h * l)
+
+	for z := 0; z < l; z++ {
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				c := Coordinate{X: x, Y: y, Z: z}
+				r, _ := m.GetRoom3(x, y, z)
+				for _, dir := range []int{N, S, E, W, U, D} {
+					next := Square.Step(c, dir)
+					if _, err := m.GetRoom3(next.X, next.Y, next.Z); err != nil {
+						continue
+					}
+					g.AddExit(index(c), SquareMoves[dir], index(next), SquareMoves[Opposite[dir]], wallFacing(r.Walls, dir))
+				}
+				if r.Treasure {
+					g.SetTreasure(index(c))
+				}
+			}
+		}
+	}
+
+	ix, iy, iz := m.Icarus3()
+	g.SetStartPoint(index(Coordinate{X: ix, Y: iy, Z: iz}))
+	return g
+}
+
+// GraphFromHex builds a graph with the same rooms and walls as a
+// hexagonal maze. Each room is a node numbered y*width+x, with its exits
+// labelled by DirectionName.
+func GraphFromHex(m *HexMaze) *GraphMaze {
+	w, h := m.Width(), m.Height()
+	g := NewGraphMaze(w * h)
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			r, _ := m.GetRoom(x, y)
+			for _, dir := range Hexagonal.Directions {
+				nx, ny, ok := m.Neighbour(x, y, dir)
+				if !ok {
+					continue
+				}
+				g.AddExit(y*w+x, DirectionName[dir], ny*w+nx, DirectionName[Opposite[dir]], r.Walls.Wall(dir))
+			}
+			if r.Treasure {
+				g.SetTreasure(y*w + x)
+			}
+		}
+	}
+
+	ix, iy := m.Icarus()
+	g.SetStartPoint(iy*w + ix)
+	return g
+}
+
+// GraphFromPolar builds a graph with the same rooms and walls as a polar
+// maze. The rooms are numbered ring by ring, from the centre outward,
+// with their exits labelled by DirectionName.
+func GraphFromPolar(m *PolarMaze) *GraphMaze {
+	first := make([]int, m.Rings()+1)
+	for r := 0; r < m.Rings(); r++ {
+		first[r+1] = first[r] + m.Cells(r)
+	}
+	g := NewGraphMaze(first[m.Rings()])
+
+	for r := 0; r < m.Rings(); r++ {
+		for c := 0; c < m.Cells(r); c++ {
+			room, _ := m.GetRoom(r, c)
+			dirs := []int{In, CW, CCW}
+			for i := range room.Walls.Outward {
+				dirs = append(dirs, Outward+i)
+			}
+			for _, dir := range dirs {
+				nr, nc, ok := m.Neighbour(r, c, dir)
+				if !ok {
+					continue
+				}
+				g.AddExit(first[r]+c, DirectionName[dir], first[nr]+nc, DirectionName[m.reverse(r, c, dir)], room.Walls.Wall(dir))
+			}
+			if room.Treasure {
+				g.SetTreasure(first[r] + c)
+			}
+		}
+	}
+
+	ir, ic := m.Icarus()
+	g.SetStartPoint(first[ir] + ic)
+	return g
+}
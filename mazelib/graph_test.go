@@ -0,0 +1,56 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+func TestGraphMove(t *testing.T) {
+	// the corridor and its walled off twin, as graphs of nodes 0, 1 and 2
+	walled := func() *GraphMaze {
+		m := NewFullGrid(3, 1)
+		m.SetStartPoint(0, 0)
+		m.SetTreasure(2, 0)
+		return GraphFromMaze(m)
+	}
+	open := func() *GraphMaze { return GraphFromMaze(corridor()) }
+
+	tests := []struct {
+		name    string
+		maze    func() *GraphMaze
+		moves   []string
+		err     error
+		node    int
+		back    string
+		steps   int
+		victory bool
+	}{
+		{"no moves", open, nil, nil, 0, "", 0, false},
+		{"open", open, []string{"right"}, nil, 1, "left", 1, false},
+		{"there and back", open, []string{"right", "left"}, nil, 0, "right", 2, false},
+		{"wall", walled, []string{"right"}, ErrWall, 0, "", 0, false},
+		{"perimeter", open, []string{"left"}, ErrInvalidDirection, 0, "", 0, false},
+		{"no such exit", open, []string{"outward0"}, ErrInvalidDirection, 0, "", 0, false},
+		{"onto the treasure", open, []string{"right", "right"}, nil, 2, "left", 2, true},
+		{"on from the treasure", open, []string{"right", "right", "left"}, ErrVictory, 2, "left", 2, true},
+	}
+
+	for _, tt := range tests {
+		m := tt.maze()
+		var err error
+		for _, label := range tt.moves {
+			err = m.Move(label)
+		}
+		if err != tt.err || m.Icarus() != tt.node || m.Back() != tt.back {
+			t.Errorf("%s: moved to node %d, back %q, with %v, want node %d, back %q, with %v",
+				tt.name, m.Icarus(), m.Back(), err, tt.node, tt.back, tt.err)
+		}
+		if m.Steps() != tt.steps || m.Cost() != tt.steps {
+			t.Errorf("%s: %d steps costing %d, want %d", tt.name, m.Steps(), m.Cost(), tt.steps)
+		}
+		if _, err := m.LookAround(); (err == ErrVictory) != tt.victory {
+			t.Errorf("%s: LookAround gave %v, want victory %t", tt.name, err, tt.victory)
+		}
+	}
+}
@@ -0,0 +1,417 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"errors"
+)
+
+// GridHooks are called by a GridMaze when something happens in it,
+// so that callers can keep statistics without changing the maze itself.
+// A nil hook is not called.
+type GridHooks struct {
+	// OnMove is called after Icarus takes a step
+	OnMove func(m *GridMaze)
+
+	// OnVictory is called once, after the step that takes Icarus to the
+	// treasure, or to the last of them
+	OnVictory func(m *GridMaze)
+}
+
+// GridMaze is a rectangular maze where every Room stores its own walls.
+// It is the maze used by daedalus and the example programs.
+type GridMaze struct {
+	rooms      [][]Room
+	start      Coordinate
+	end        Coordinate
+	icarus     Coordinate
+	StepsTaken int
+	CostTaken  int
+	Hooks      GridHooks
+	wrap       bool
+	painted    bool
+	portals    map[Coordinate]Coordinate
+	teleported bool
+	keys       map[string]bool
+	hoard      map[Coordinate]bool
+	pickedUp   bool
+}
+
+// NewEmptyGrid creates a maze without any walls but the perimeter
+// Good starting point for additive algorithms
+func NewEmptyGrid(width, height int) *GridMaze {
+	z := GridMaze{}
+
+	z.rooms = make([][]Room, height)
+	for y := 0; y < height; y++ {
+		z.rooms[y] = make([]Room, width)
+	}
+
+	// Add perimeter walls for top and bottom
+	for x := 0; x < width; x++ {
+		z.rooms[0][x].AddWall(N)
+		z.rooms[height-1][x].AddWall(S)
+	}
+
+	// Add perimeter walls for left and right
+	for y := 0; y < height; y++ {
+		z.rooms[y][0].AddWall(W)
+		z.rooms[y][width-1].AddWall(E)
+	}
+
+	// A single level has a floor and ceiling everywhere
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			z.rooms[y][x].AddWall(U)
+			z.rooms[y][x].AddWall(D)
+		}
+	}
+
+	return &z
+}
+
+// NewFullGrid creates a maze with all walls
+// Good starting point for subtractive algorithms
+func NewFullGrid(width, height int) *GridMaze {
+	z := NewEmptyGrid(width, height)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			z.rooms[y][x].Walls = Survey{Top: true, Right: true, Bottom: true, Left: true, Up: true, Down: true}
+		}
+	}
+
+	return z
+}
+
+// NewEmptyTorus creates a torus without any walls.
+// Walking off one edge brings Icarus in on the opposite edge.
+func NewEmptyTorus(width, height int) *GridMaze {
+	z := NewEmptyGrid(width, height)
+	z.wrap = true
+	for x := 0; x < width; x++ {
+		z.RmWall(x, 0, N)
+	}
+	for y := 0; y < height; y++ {
+		z.RmWall(0, y, W)
+	}
+	return z
+}
+
+// NewFullTorus creates a torus with all walls
+func NewFullTorus(width, height int) *GridMaze {
+	z := NewFullGrid(width, height)
+	z.wrap = true
+	return z
+}
+
+// NewGridFromRooms creates a maze from rows of rooms, indexed as rooms[y][x].
+// The rooms are used as given, so the start and treasure are taken from
+// the Start and Treasure flags of the rooms. As the maze has a single
+// level, every room gets a floor and ceiling.
+func NewGridFromRooms(rooms [][]Room) (*GridMaze, error) {
+	if len(rooms) == 0 || len(rooms[0]) == 0 {
+		return nil, errors.New("maze must have at least one room")
+	}
+
+	z := GridMaze{rooms: rooms}
+	for y, row := range rooms {
+		if len(row) != len(rooms[0]) {
+			return nil, errors.New("all rows of the maze must have the same width")
+		}
+		for x, r := range row {
+			rooms[y][x].AddWall(U)
+			rooms[y][x].AddWall(D)
+			if r.Start {
+				z.start = Coordinate{X: x, Y: y}
+				z.icarus = z.start
+			}
+			if r.Treasure {
+				z.end = Coordinate{X: x, Y: y}
+			}
+		}
+	}
+
+	return &z, nil
+}
+
+// GetRoom returns a Room struct
+func (m *GridMaze) GetRoom(x, y int) (*Room, error) {
+	if x < 0 || y < 0 || x >= m.Width() || y >= m.Height() {
+		return &Room{}, ErrOutOfBounds
+	}
+
+	return &m.rooms[y][x], nil
+}
+
+// Width returns width of the maze
+func (m *GridMaze) Width() int { return len(m.rooms[0]) }
+
+// Height returns height of the maze
+func (m *GridMaze) Height() int { return len(m.rooms) }
+
+// Steps returns the number of steps Icarus has taken
+func (m *GridMaze) Steps() int { return m.StepsTaken }
+
+// Wraps returns if the maze is a torus
+func (m *GridMaze) Wraps() bool { return m.wrap }
+
+// neighbour returns the location next to (x, y) in the given direction,
+// or false if it is outside of the maze.
+// On a torus it wraps around to the opposite edge.
+func (m *GridMaze) neighbour(x, y, dir int) (nx, ny int, ok bool) {
+	c, ok := neighbour(Coordinate{X: x, Y: y}, dir, m.Width(), m.Height(), m.wrap)
+	return c.X, c.Y, ok
+}
+
+// Icarus returns the finder's current position
+func (m *GridMaze) Icarus() (x, y int) {
+	return m.icarus.X, m.icarus.Y
+}
+
+// SetStartPoint sets the location where Icarus will awake
+func (m *GridMaze) SetStartPoint(x, y int) error {
+	r, err := m.GetRoom(x, y)
+
+	if err != nil {
+		return err
+	}
+
+	if r.Treasure {
+		return errors.New("can't start in the treasure")
+	}
+
+	if r.Excluded {
+		return errors.New("can't start inside rock")
+	}
+
+	if r.Portal {
+		return errors.New("can't start in a portal")
+	}
+
+	r.Start = true
+	m.start = Coordinate{X: x, Y: y}
+	m.icarus = m.start
+	return nil
+}
+
+// SetTreasure sets the location of the treasure for a given maze
+func (m *GridMaze) SetTreasure(x, y int) error {
+	r, err := m.GetRoom(x, y)
+
+	if err != nil {
+		return err
+	}
+
+	if r.Start {
+		return errors.New("can't have the treasure at the start")
+	}
+
+	if r.Excluded {
+		return errors.New("can't have the treasure inside rock")
+	}
+
+	if r.Portal {
+		return errors.New("can't have the treasure in a portal")
+	}
+
+	r.Treasure = true
+	m.end = Coordinate{X: x, Y: y}
+	return nil
+}
+
+// LookAround Given Icarus's current location, Discover that room
+// Will return ErrVictory if Icarus is at the treasure.
+func (m *GridMaze) LookAround() (Survey, error) {
+	if m.victory() {
+		return Survey{}, ErrVictory
+	}
+
+	return m.Discover(m.icarus.X, m.icarus.Y)
+}
+
+// Discover Given two points, survey the room.
+// Will return error if two points are outside of the maze
+func (m *GridMaze) Discover(x, y int) (Survey, error) {
+	r, err := m.GetRoom(x, y)
+	if err != nil {
+		return Survey{}, err
+	}
+
+	s := r.Walls
+	if m.painted {
+		for _, dir := range []int{N, S, E, W} {
+			if nx, ny, ok := m.neighbour(x, y, dir); ok {
+				s.setCost(dir, TerrainCost[m.rooms[ny][nx].Terrain])
+			}
+		}
+	}
+	return s, nil
+}
+
+// Paint sets the terrain of room (x, y). Rock can't be painted.
+func (m *GridMaze) Paint(x, y int, t Terrain) {
+	if r, err := m.GetRoom(x, y); err == nil && !r.Excluded {
+		r.Terrain = t
+		m.painted = true
+	}
+}
+
+// Cost returns the total cost of the rooms Icarus has entered
+func (m *GridMaze) Cost() int { return m.CostTaken }
+
+// move Moves Icarus's position one step in the given direction
+// Will not permit moving through walls or out of the maze
+func (m *GridMaze) move(dir int) error {
+	s, e := m.LookAround()
+	if e != nil {
+		return e
+	}
+	if wallFacing(s, dir) {
+		return ErrWall
+	}
+	if lock := s.Lock(dir); lock != "" && !m.keys[lock] {
+		return ErrLocked
+	}
+
+	x, y, ok := m.neighbour(m.icarus.X, m.icarus.Y, dir)
+	if !ok {
+		return ErrOutOfBounds
+	}
+
+	m.icarus = Coordinate{X: x, Y: y}
+	m.StepsTaken++
+	m.CostTaken += TerrainCost[m.rooms[y][x].Terrain]
+	if k := m.rooms[y][x].Key; k != "" {
+		if m.keys == nil {
+			m.keys = make(map[string]bool)
+		}
+		m.keys[k] = true
+	}
+	m.teleported = false
+	if to, ok := m.portals[m.icarus]; ok {
+		m.icarus = to
+		m.teleported = true
+	}
+	m.pickUp()
+	if m.Hooks.OnMove != nil {
+		m.Hooks.OnMove(m)
+	}
+	if m.Hooks.OnVictory != nil && m.victory() {
+		m.Hooks.OnVictory(m)
+	}
+	return nil
+}
+
+// Link joins rooms a and b with a portal. Entering either room takes
+// Icarus to the other one, in the same step.
+func (m *GridMaze) Link(a, b Coordinate) error {
+	if a == b {
+		return errors.New("can't link a room to itself")
+	}
+	for _, c := range []Coordinate{a, b} {
+		r, err := m.GetRoom(c.X, c.Y)
+		if err != nil {
+			return err
+		}
+		if r.Excluded || r.Treasure {
+			return errors.New("can't have a portal inside rock or in the treasure")
+		}
+		if r.Portal {
+			return errors.New("room is already a portal")
+		}
+	}
+
+	if m.portals == nil {
+		m.portals = make(map[Coordinate]Coordinate)
+	}
+	m.portals[a] = b
+	m.portals[b] = a
+	m.rooms[a.Y][a.X].Portal = true
+	m.rooms[b.Y][b.X].Portal = true
+	return nil
+}
+
+// Portal returns the room the portal at (x, y) leads to,
+// or false if there is no portal
+func (m *GridMaze) Portal(x, y int) (Coordinate, bool) {
+	c, ok := m.portals[Coordinate{X: x, Y: y}]
+	return c, ok
+}
+
+// Teleported returns if Icarus went through a portal on his last step
+func (m *GridMaze) Teleported() bool { return m.teleported }
+
+// MoveLeft Moves Icarus's position left one step
+// Will not permit moving through walls or out of the maze
+func (m *GridMaze) MoveLeft() error { return m.move(W) }
+
+// MoveRight Moves Icarus's position right one step
+// Will not permit moving through walls or out of the maze
+func (m *GridMaze) MoveRight() error { return m.move(E) }
+
+// MoveUp Moves Icarus's position up one step
+// Will not permit moving through walls or out of the maze
+func (m *GridMaze) MoveUp() error { return m.move(N) }
+
+// MoveDown Moves Icarus's position down one step
+// Will not permit moving through walls or out of the maze
+func (m *GridMaze) MoveDown() error { return m.move(S) }
+
+// MoveAbove Moves Icarus's position to the level above
+// There is only one level, so this is never permitted
+func (m *GridMaze) MoveAbove() error { return m.move(U) }
+
+// MoveBelow Moves Icarus's position to the level below
+// There is only one level, so this is never permitted
+func (m *GridMaze) MoveBelow() error { return m.move(D) }
+
+// AddWall adds a wall to room (x, y) and the matching wall to its neighbour
+func (m *GridMaze) AddWall(x, y, dir int) {
+	if r, err := m.GetRoom(x, y); err == nil {
+		r.AddWall(dir)
+	}
+	if nx, ny, ok := m.neighbour(x, y, dir); ok {
+		m.rooms[ny][nx].AddWall(Opposite[dir])
+	}
+}
+
+// RmWall removes a wall from room (x, y) and the matching wall from its neighbour
+func (m *GridMaze) RmWall(x, y, dir int) {
+	if r, err := m.GetRoom(x, y); err == nil {
+		r.RmWall(dir)
+	}
+	if nx, ny, ok := m.neighbour(x, y, dir); ok {
+		m.rooms[ny][nx].RmWall(Opposite[dir])
+	}
+}
+
+// OneWay turns the wall of room (x, y) facing the given direction into
+// a one way door. Icarus can go through it from room (x, y), but the wall
+// is still there on the other side.
+func (m *GridMaze) OneWay(x, y, dir int) {
+	if r, err := m.GetRoom(x, y); err == nil {
+		r.RmWall(dir)
+	}
+	if nx, ny, ok := m.neighbour(x, y, dir); ok {
+		m.rooms[ny][nx].AddWall(Opposite[dir])
+	}
+}
+
+// Exclude turns room (x, y) into solid rock, walled off from its neighbours
+func (m *GridMaze) Exclude(x, y int) {
+	r, err := m.GetRoom(x, y)
+	if err != nil {
+		return
+	}
+	r.Excluded = true
+	for _, dir := range []int{N, S, E, W} {
+		m.AddWall(x, y, dir)
+	}
+}
+
+// Excluded returns if room (x, y) is solid rock
+func (m *GridMaze) Excluded(x, y int) bool {
+	r, err := m.GetRoom(x, y)
+	return err == nil && r.Excluded
+}
@@ -0,0 +1,82 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+func TestGridHooks(t *testing.T) {
+	tests := []struct {
+		name      string
+		moves     []int
+		looks     int
+		onMove    int
+		onVictory int
+		victory   bool
+	}{
+		{"no moves", nil, 3, 0, 0, false},
+		{"one step", []int{E}, 1, 1, 0, false},
+		{"into a wall", []int{N}, 0, 0, 0, false},
+		{"to the treasure", []int{E, E}, 0, 2, 1, true},
+		{"looking around at the treasure", []int{E, E}, 3, 2, 1, true},
+		{"moving on from the treasure", []int{E, E, W}, 1, 2, 1, true},
+	}
+
+	for _, tt := range tests {
+		m := corridor()
+		moves, victories := 0, 0
+		m.Hooks.OnMove = func(*GridMaze) { moves++ }
+		m.Hooks.OnVictory = func(*GridMaze) { victories++ }
+
+		for _, dir := range tt.moves {
+			m.move(dir)
+		}
+		var err error
+		for i := 0; i < tt.looks; i++ {
+			_, err = m.LookAround()
+		}
+		if tt.looks > 0 && (err == ErrVictory) != tt.victory {
+			t.Errorf("%s: LookAround gave %v, want victory %t", tt.name, err, tt.victory)
+		}
+		if moves != tt.onMove || victories != tt.onVictory {
+			t.Errorf("%s: OnMove called %d times and OnVictory %d times, want %d and %d",
+				tt.name, moves, victories, tt.onMove, tt.onVictory)
+		}
+	}
+}
+
+func TestGridMove(t *testing.T) {
+	tests := []struct {
+		name  string
+		maze  func() *GridMaze
+		dir   int
+		err   error
+		x, y  int
+		steps int
+	}{
+		{"open", corridor, E, nil, 1, 0, 1},
+		{"wall", corridor, S, ErrWall, 0, 0, 0},
+		{"perimeter", corridor, W, ErrWall, 0, 0, 0},
+		{"across the edge of a torus", func() *GridMaze {
+			m := NewEmptyTorus(3, 1)
+			m.SetStartPoint(0, 0)
+			m.SetTreasure(1, 0)
+			return m
+		}, W, nil, 2, 0, 1},
+		{"out of a maze without walls", func() *GridMaze {
+			m, _ := NewGridFromRooms([][]Room{{{Start: true}, {Treasure: true}}})
+			return m
+		}, N, ErrOutOfBounds, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		m := tt.maze()
+		if err := m.move(tt.dir); err != tt.err {
+			t.Errorf("%s: move gave %v, want %v", tt.name, err, tt.err)
+		}
+		if x, y := m.Icarus(); x != tt.x || y != tt.y || m.Steps() != tt.steps {
+			t.Errorf("%s: Icarus at (%d, %d) after %d steps, want (%d, %d) after %d", tt.name, x, y, m.Steps(), tt.x, tt.y, tt.steps)
+		}
+	}
+}
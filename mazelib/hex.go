@@ -0,0 +1,300 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// HexSurvey Given a location, survey the six sides of a hexagonal room
+// True indicates a wall is present.
+type HexSurvey struct {
+	N  bool `json:"n"`
+	NE bool `json:"ne"`
+	SE bool `json:"se"`
+	S  bool `json:"s"`
+	SW bool `json:"sw"`
+	NW bool `json:"nw"`
+}
+
+// Wall returns if there is a wall in the given direction
+func (s HexSurvey) Wall(dir int) bool {
+	switch dir {
+	case N:
+		return s.N
+	case NE:
+		return s.NE
+	case SE:
+		return s.SE
+	case S:
+		return s.S
+	case SW:
+		return s.SW
+	case NW:
+		return s.NW
+	}
+	return true
+}
+
+// Open returns the directions without walls
+func (s HexSurvey) Open() []int {
+	dirs := make([]int, 0, 6)
+	for _, dir := range Hexagonal.Directions {
+		if !s.Wall(dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+func (s *HexSurvey) set(dir int, wall bool) {
+	switch dir {
+	case N:
+		s.N = wall
+	case NE:
+		s.NE = wall
+	case SE:
+		s.SE = wall
+	case S:
+		s.S = wall
+	case SW:
+		s.SW = wall
+	case NW:
+		s.NW = wall
+	}
+}
+
+// HexRoom is a room of a hexagonal maze
+type HexRoom struct {
+	Treasure bool
+	Start    bool
+	Walls    HexSurvey
+}
+
+// HexReply is a HexSurvey sent to a solver, see MazeReply
+type HexReply struct {
+	Survey HexSurvey
+	Err    error
+}
+
+// HexMaze is a maze of hexagonal rooms with flat tops. Rooms are stored
+// in columns, with every odd column shifted half a room down, so that
+// the maze is roughly rectangular.
+type HexMaze struct {
+	rooms      [][]HexRoom
+	start      Coordinate
+	end        Coordinate
+	icarus     Coordinate
+	StepsTaken int
+}
+
+// NewFullHexMaze creates a hexagonal maze with all walls
+// Good starting point for subtractive algorithms
+func NewFullHexMaze(width, height int) *HexMaze {
+	z := HexMaze{}
+
+	z.rooms = make([][]HexRoom, height)
+	for y := 0; y < height; y++ {
+		z.rooms[y] = make([]HexRoom, width)
+		for x := 0; x < width; x++ {
+			z.rooms[y][x].Walls = HexSurvey{N: true, NE: true, SE: true, S: true, SW: true, NW: true}
+		}
+	}
+
+	return &z
+}
+
+// Width returns width of the maze
+func (m *HexMaze) Width() int { return len(m.rooms[0]) }
+
+// Height returns height of the maze
+func (m *HexMaze) Height() int { return len(m.rooms) }
+
+// Steps returns the number of steps Icarus has taken
+func (m *HexMaze) Steps() int { return m.StepsTaken }
+
+// Cost returns the total cost of Icarus's steps, which all cost 1
+// in a hexagonal maze
+func (m *HexMaze) Cost() int { return m.StepsTaken }
+
+// Axial returns the axial coordinates of room (x, y), see Hexagonal
+func (m *HexMaze) Axial(x, y int) Coordinate {
+	return Coordinate{X: x, Y: y - (x-x&1)/2}
+}
+
+// Offset returns the room (x, y) at axial coordinates c
+func (m *HexMaze) Offset(c Coordinate) (x, y int) {
+	return c.X, c.Y + (c.X-c.X&1)/2
+}
+
+// Neighbour returns the room next to (x, y) in the given direction,
+// or false if it is outside of the maze
+func (m *HexMaze) Neighbour(x, y, dir int) (nx, ny int, ok bool) {
+	if _, found := Hexagonal.Delta[dir]; !found {
+		return x, y, false
+	}
+	nx, ny = m.Offset(Hexagonal.Step(m.Axial(x, y), dir))
+	return nx, ny, nx >= 0 && ny >= 0 && nx < m.Width() && ny < m.Height()
+}
+
+// GetRoom returns the room at (x, y)
+func (m *HexMaze) GetRoom(x, y int) (*HexRoom, error) {
+	if x < 0 || y < 0 || x >= m.Width() || y >= m.Height() {
+		return &HexRoom{}, ErrOutOfBounds
+	}
+
+	return &m.rooms[y][x], nil
+}
+
+// Icarus returns the finder's current position
+func (m *HexMaze) Icarus() (x, y int) {
+	return m.icarus.X, m.icarus.Y
+}
+
+// SetStartPoint sets the location where Icarus will awake
+func (m *HexMaze) SetStartPoint(x, y int) error {
+	r, err := m.GetRoom(x, y)
+	if err != nil {
+		return err
+	}
+
+	if r.Treasure {
+		return errors.New("can't start in the treasure")
+	}
+
+	r.Start = true
+	m.start = Coordinate{X: x, Y: y}
+	m.icarus = m.start
+	return nil
+}
+
+// SetTreasure sets the location of the treasure for a given maze
+func (m *HexMaze) SetTreasure(x, y int) error {
+	r, err := m.GetRoom(x, y)
+	if err != nil {
+		return err
+	}
+
+	if r.Start {
+		return errors.New("can't have the treasure at the start")
+	}
+
+	r.Treasure = true
+	m.end = Coordinate{X: x, Y: y}
+	return nil
+}
+
+// LookAround Given Icarus's current location, Discover that room
+// Will return ErrVictory if Icarus is at the treasure.
+func (m *HexMaze) LookAround() (HexSurvey, error) {
+	if m.end == m.icarus {
+		return HexSurvey{}, ErrVictory
+	}
+
+	return m.Discover(m.icarus.X, m.icarus.Y)
+}
+
+// Discover Given two points, survey the room.
+// Will return error if two points are outside of the maze
+func (m *HexMaze) Discover(x, y int) (HexSurvey, error) {
+	r, err := m.GetRoom(x, y)
+	if err != nil {
+		return HexSurvey{}, err
+	}
+	return r.Walls, nil
+}
+
+// Move Moves Icarus's position one step in the given direction
+// Will not permit moving through walls or out of the maze
+func (m *HexMaze) Move(dir int) error {
+	s, e := m.LookAround()
+	if e != nil {
+		return e
+	}
+	if s.Wall(dir) {
+		return ErrWall
+	}
+
+	nx, ny, ok := m.Neighbour(m.icarus.X, m.icarus.Y, dir)
+	if !ok {
+		return ErrOutOfBounds
+	}
+
+	m.icarus = Coordinate{X: nx, Y: ny}
+	m.StepsTaken++
+	return nil
+}
+
+// AddWall adds a wall to room (x, y) and the matching wall to its neighbour
+func (m *HexMaze) AddWall(x, y, dir int) {
+	m.setWall(x, y, dir, true)
+}
+
+// RmWall removes a wall from room (x, y) and the matching wall from its neighbour
+func (m *HexMaze) RmWall(x, y, dir int) {
+	m.setWall(x, y, dir, false)
+}
+
+func (m *HexMaze) setWall(x, y, dir int, wall bool) {
+	if r, err := m.GetRoom(x, y); err == nil {
+		r.Walls.set(dir, wall)
+	}
+	if nx, ny, ok := m.Neighbour(x, y, dir); ok {
+		m.rooms[ny][nx].Walls.set(Opposite[dir], wall)
+	}
+}
+
+// hexSide is the length of a side of a hexagon in the SVG, in pixels
+const hexSide = 12.0
+
+// WriteHexSVG draws the maze as an SVG image.
+// Icarus is a blue dot and the treasure a gold dot.
+func WriteHexSVG(w io.Writer, m *HexMaze) error {
+	h := math.Sqrt(3) * hexSide
+	width := hexSide*(1.5*float64(m.Width())+0.5) + 2
+	height := h*(float64(m.Height())+0.5) + 2
+
+	if _, err := fmt.Fprintf(w, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%.0f\" height=\"%.0f\">\n"+
+		"<rect width=\"100%%\" height=\"100%%\" fill=\"white\"/>\n"+
+		"<g stroke=\"black\" stroke-width=\"2\" stroke-linecap=\"round\">\n", width, height); err != nil {
+		return err
+	}
+
+	// the sides of a hexagon, from the corner at 0 degrees clockwise
+	sides := []int{SE, S, SW, NW, N, NE}
+	ix, iy := m.Icarus()
+	dots := ""
+	for y := 0; y < m.Height(); y++ {
+		for x := 0; x < m.Width(); x++ {
+			r := &m.rooms[y][x]
+			cx := hexSide*(1+1.5*float64(x)) + 1
+			cy := h*(float64(y)+0.5+0.5*float64(x&1)) + 1
+
+			for i, dir := range sides {
+				if !r.Walls.Wall(dir) {
+					continue
+				}
+				a1 := math.Pi / 3 * float64(i)
+				a2 := math.Pi / 3 * float64(i+1)
+				if _, err := fmt.Fprintf(w, "<line x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\"/>\n",
+					cx+hexSide*math.Cos(a1), cy+hexSide*math.Sin(a1),
+					cx+hexSide*math.Cos(a2), cy+hexSide*math.Sin(a2)); err != nil {
+					return err
+				}
+			}
+
+			switch {
+			case r.Treasure:
+				dots += fmt.Sprintf("<circle cx=\"%.1f\" cy=\"%.1f\" r=\"4\" fill=\"gold\"/>\n", cx, cy)
+			case x == ix && y == iy:
+				dots += fmt.Sprintf("<circle cx=\"%.1f\" cy=\"%.1f\" r=\"4\" fill=\"blue\"/>\n", cx, cy)
+			}
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "</g>\n%s</svg>\n", dots)
+	return err
+}
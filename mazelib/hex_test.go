@@ -0,0 +1,60 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+func TestHexMove(t *testing.T) {
+	// odd columns are shifted half a room down, so the rooms to the
+	// north east and south east depend on the column
+	tests := []struct {
+		name     string
+		x, y     int
+		open     []int
+		moves    []int
+		err      error
+		nx, ny   int
+		steps    int
+		treasure bool
+	}{
+		{"south", 0, 0, []int{S}, []int{S}, nil, 0, 1, 1, false},
+		{"south east of an even column", 0, 1, []int{SE}, []int{SE}, nil, 1, 1, 1, false},
+		{"north east of an even column", 0, 1, []int{NE}, []int{NE}, nil, 1, 0, 1, false},
+		{"south east of an odd column", 1, 0, []int{SE}, []int{SE}, nil, 2, 1, 1, false},
+		{"north west of an odd column", 1, 1, []int{NW}, []int{NW}, nil, 0, 1, 1, false},
+		{"there and back", 1, 1, []int{SW}, []int{SW, NE}, nil, 1, 1, 2, false},
+		{"wall", 1, 1, nil, []int{N}, ErrWall, 1, 1, 0, false},
+		{"square direction", 1, 1, nil, []int{E}, ErrWall, 1, 1, 0, false},
+		{"off the edge", 0, 0, []int{NW}, []int{NW}, ErrOutOfBounds, 0, 0, 0, false},
+		{"onto the treasure", 1, 1, []int{S}, []int{S}, nil, 1, 2, 1, true},
+		{"on from the treasure", 1, 1, []int{S, N}, []int{S, N}, ErrVictory, 1, 2, 1, true},
+	}
+
+	for _, tt := range tests {
+		m := NewFullHexMaze(3, 3)
+		m.SetStartPoint(tt.x, tt.y)
+		for _, dir := range tt.open {
+			m.RmWall(tt.x, tt.y, dir)
+		}
+		if tt.treasure {
+			m.SetTreasure(1, 2)
+		} else {
+			m.SetTreasure(2, 2)
+		}
+		var err error
+		for _, dir := range tt.moves {
+			err = m.Move(dir)
+		}
+		if x, y := m.Icarus(); err != tt.err || x != tt.nx || y != tt.ny {
+			t.Errorf("%s: moved to (%d, %d) with %v, want (%d, %d) with %v", tt.name, x, y, err, tt.nx, tt.ny, tt.err)
+		}
+		if m.Steps() != tt.steps || m.Cost() != tt.steps {
+			t.Errorf("%s: %d steps costing %d, want %d", tt.name, m.Steps(), m.Cost(), tt.steps)
+		}
+		if _, err := m.LookAround(); (err == ErrVictory) != tt.treasure {
+			t.Errorf("%s: LookAround gave %v, want victory %t", tt.name, err, tt.treasure)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"math/rand"
+)
+
+// Hints of how close Icarus is to the treasure, from the furthest
+var Hints = []string{"cold", "warm", "hot"}
+
+// Heat returns how hot a hint is, from 1 for cold to 3 for hot,
+// or 0 for no hint
+func Heat(hint string) int {
+	for i, h := range Hints {
+		if h == hint {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Proximity returns how far room (x, y) is from the nearest treasure,
+// walking if byPath is true and as the crow flies, without diagonals,
+// otherwise. It returns -1 if there is no treasure to go to.
+func Proximity(m MazeI, x, y int, byPath bool) int {
+	src := Coordinate{X: x, Y: y}
+	w, h := m.Width(), m.Height()
+	if byPath {
+		nearest := -1
+		for c, d := range distances(m, src, -1) {
+			if r, _ := m.GetRoom(c.X, c.Y); r.Treasure && (nearest < 0 || d < nearest) {
+				nearest = d
+			}
+		}
+		return nearest
+	}
+
+	torus := isTorus(m)
+	nearest := -1
+	for j := 0; j < h; j++ {
+		for i := 0; i < w; i++ {
+			if r, _ := m.GetRoom(i, j); !r.Treasure {
+				continue
+			}
+			dx, dy := gap(i, x, w, torus), gap(j, y, h, torus)
+			if nearest < 0 || dx+dy < nearest {
+				nearest = dx + dy
+			}
+		}
+	}
+	return nearest
+}
+
+// gap returns how far apart a and b are on an axis of size n,
+// which wraps around on a torus
+func gap(a, b, n int, torus bool) int {
+	d := a - b
+	if d < 0 {
+		d = -d
+	}
+	if torus && n-d < d {
+		d = n - d
+	}
+	return d
+}
+
+// Hint buckets distance d in a w x h maze as hot, warm or cold.
+// With noise percent chance, the hint is one bucket off.
+func Hint(d, w, h, noise int) string {
+	i := 0
+	switch {
+	case d < 0:
+		return ""
+	case d <= (w+h)/5:
+		i = 2
+	case d <= (w+h)/2:
+		i = 1
+	}
+
+	if noise > 0 && rand.Intn(100) < noise {
+		switch {
+		case i == 0:
+			i++
+		case i == len(Hints)-1:
+			i--
+		default:
+			i += 2*rand.Intn(2) - 1
+		}
+	}
+	return Hints[i]
+}
@@ -0,0 +1,104 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+func TestHeat(t *testing.T) {
+	tests := []struct {
+		hint string
+		heat int
+	}{
+		{"", 0},
+		{"cold", 1},
+		{"warm", 2},
+		{"hot", 3},
+		{"tepid", 0},
+	}
+
+	for _, tt := range tests {
+		if got := Heat(tt.hint); got != tt.heat {
+			t.Errorf("Heat(%q) is %d, want %d", tt.hint, got, tt.heat)
+		}
+	}
+}
+
+func TestProximity(t *testing.T) {
+	// the treasure at the bottom of the last tooth of a comb
+	comb := func() *GridMaze {
+		m := combGrid()
+		m.SetStartPoint(0, 0)
+		m.SetTreasure(7, 7)
+		return m
+	}
+	torus := func() *GridMaze {
+		m := NewEmptyTorus(8, 8)
+		m.SetStartPoint(0, 0)
+		m.SetTreasure(7, 7)
+		return m
+	}
+
+	tests := []struct {
+		name   string
+		maze   func() *GridMaze
+		x, y   int
+		byPath bool
+		want   int
+	}{
+		{"start by path", comb, 0, 0, true, 14},
+		{"start as the crow flies", comb, 0, 0, false, 14},
+		{"next tooth by path", comb, 6, 7, true, 15},
+		{"next tooth as the crow flies", comb, 6, 7, false, 1},
+		{"treasure", comb, 7, 7, true, 0},
+		{"across the edges by path", torus, 0, 0, true, 2},
+		{"across the edges as the crow flies", torus, 0, 0, false, 2},
+		{"nearest of two", func() *GridMaze {
+			m := comb()
+			m.AddTreasure(2, 0)
+			return m
+		}, 0, 0, true, 2},
+		{"no treasure", combGrid, 0, 0, true, -1},
+		{"no treasure as the crow flies", combGrid, 0, 0, false, -1},
+	}
+
+	for _, tt := range tests {
+		if got := Proximity(tt.maze(), tt.x, tt.y, tt.byPath); got != tt.want {
+			t.Errorf("%s: Proximity is %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHint(t *testing.T) {
+	// in a 10 x 10 maze, hot is up to 4 away and warm up to 10
+	tests := []struct {
+		d     int
+		noise int
+		want  []string
+	}{
+		{-1, 0, []string{""}},
+		{0, 0, []string{"hot"}},
+		{4, 0, []string{"hot"}},
+		{5, 0, []string{"warm"}},
+		{10, 0, []string{"warm"}},
+		{11, 0, []string{"cold"}},
+		{-1, 100, []string{""}},
+		{4, 100, []string{"warm"}},
+		{5, 100, []string{"hot", "cold"}},
+		{11, 100, []string{"warm"}},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			got := Hint(tt.d, 10, 10, tt.noise)
+			ok := false
+			for _, h := range tt.want {
+				ok = ok || got == h
+			}
+			if !ok {
+				t.Fatalf("Hint(%d) with %d%% noise is %q, want one of %q", tt.d, tt.noise, got, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// AddTreasure adds another treasure to room (x, y). Once a maze has more
+// than one treasure, Icarus picks each up as he enters its room and
+// victory needs all of them. The treasure set with SetTreasure is one of
+// them.
+func (m *GridMaze) AddTreasure(x, y int) error {
+	r, err := m.GetRoom(x, y)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case r.Start:
+		return errors.New("can't have the treasure at the start")
+	case r.Excluded:
+		return errors.New("can't have the treasure inside rock")
+	case r.Portal:
+		return errors.New("can't have the treasure in a portal")
+	case r.Treasure:
+		return errors.New("there is a treasure there already")
+	}
+
+	if m.hoard == nil {
+		m.hoard = make(map[Coordinate]bool)
+		if e, err := m.GetRoom(m.end.X, m.end.Y); err == nil && e.Treasure {
+			m.hoard[m.end] = true
+		}
+	}
+	r.Treasure = true
+	m.hoard[Coordinate{X: x, Y: y}] = true
+	return nil
+}
+
+// Remaining returns the number of treasures left to pick up,
+// or 0 if the maze has a single treasure
+func (m *GridMaze) Remaining() int { return len(m.hoard) }
+
+// PickedUp returns if Icarus picked up a treasure on his last step
+func (m *GridMaze) PickedUp() bool { return m.pickedUp }
+
+// pickUp picks up the treasure in the room Icarus is in, if any
+func (m *GridMaze) pickUp() {
+	m.pickedUp = m.hoard[m.icarus]
+	if m.pickedUp {
+		delete(m.hoard, m.icarus)
+		m.rooms[m.icarus.Y][m.icarus.X].Treasure = false
+	}
+}
+
+// victory returns if Icarus has found the treasure, or all of them
+func (m *GridMaze) victory() bool {
+	if m.hoard != nil {
+		return len(m.hoard) == 0
+	}
+	return m.end == m.icarus
+}
+
+// Hoarder is implemented by mazes that can have several treasures
+type Hoarder interface {
+	MazeI
+	AddTreasure(x, y int) error
+	Remaining() int
+	PickedUp() bool
+}
+
+// ScatterTreasures adds n more treasures to the maze, in rooms that can
+// be reached from Icarus. It returns the number of treasures added.
+func ScatterTreasures(m Hoarder, n int) int {
+	ix, iy := m.Icarus()
+	rooms := Reachable(m, ix, iy)
+
+	added := 0
+	for _, i := range rand.Perm(len(rooms)) {
+		if added == n {
+			break
+		}
+		if m.AddTreasure(rooms[i].X, rooms[i].Y) == nil {
+			added++
+		}
+	}
+	return added
+}
@@ -0,0 +1,58 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+// twoTreasures is a 5 x 1 corridor with Icarus at the west end, and treasures
+// in the middle and at the east end
+func twoTreasures() *GridMaze {
+	m := NewFullGrid(5, 1)
+	serpentine(m)
+	m.SetStartPoint(0, 0)
+	m.SetTreasure(4, 0)
+	m.AddTreasure(2, 0)
+	return m
+}
+
+func TestHoard(t *testing.T) {
+	tests := []struct {
+		name      string
+		moves     []int
+		remaining int
+		pickedUp  bool
+		victory   bool
+	}{
+		{"no moves", nil, 2, false, false},
+		{"next to a treasure", []int{E}, 2, false, false},
+		{"onto a treasure", []int{E, E}, 1, true, false},
+		{"off a treasure", []int{E, E, E}, 1, false, false},
+		{"back onto a treasure picked up", []int{E, E, E, W}, 1, false, false},
+		{"onto the last treasure", []int{E, E, E, E}, 0, true, true},
+	}
+
+	for _, tt := range tests {
+		m := twoTreasures()
+		for _, dir := range tt.moves {
+			m.move(dir)
+		}
+		if m.Remaining() != tt.remaining || m.PickedUp() != tt.pickedUp {
+			t.Errorf("%s: %d treasures remaining, picked up %t, want %d and %t",
+				tt.name, m.Remaining(), m.PickedUp(), tt.remaining, tt.pickedUp)
+		}
+		if _, err := m.LookAround(); (err == ErrVictory) != tt.victory {
+			t.Errorf("%s: LookAround gave %v, want victory %t", tt.name, err, tt.victory)
+		}
+	}
+}
+
+func TestSolveHoard(t *testing.T) {
+	// Solve only stops at victory, so the solver must pick up both
+	// treasures of the corridor, even the one it passes first
+	m := twoTreasures()
+	if steps, found := Solve(m, FindTreasure, 0); !found || steps != 4 || m.Remaining() != 0 {
+		t.Errorf("found %t in %d steps, with %d treasures left, want 4 steps and none left", found, steps, m.Remaining())
+	}
+}
@@ -0,0 +1,207 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+)
+
+// KeyColours are the colours of the keys, in the order they are placed
+var KeyColours = []string{"red", "green", "blue", "yellow", "purple", "orange"}
+
+// Lock returns the colour of the key needed to go in the given direction,
+// or "" if there is no locked door
+func (s Survey) Lock(dir int) string {
+	switch dir {
+	case N:
+		return s.TopLock
+	case S:
+		return s.BottomLock
+	case E:
+		return s.RightLock
+	case W:
+		return s.LeftLock
+	}
+	return ""
+}
+
+// setLock sets the colour of the lock in the given direction
+func (s *Survey) setLock(dir int, colour string) {
+	switch dir {
+	case N:
+		s.TopLock = colour
+	case S:
+		s.BottomLock = colour
+	case E:
+		s.RightLock = colour
+	case W:
+		s.LeftLock = colour
+	}
+}
+
+// Lock puts a locked door in the passage from room (x, y) in the given
+// direction. Icarus needs the key of that colour to go through it, from
+// either side.
+func (m *GridMaze) Lock(x, y, dir int, colour string) {
+	if r, err := m.GetRoom(x, y); err == nil {
+		r.Walls.setLock(dir, colour)
+	}
+	if nx, ny, ok := m.neighbour(x, y, dir); ok {
+		m.rooms[ny][nx].Walls.setLock(Opposite[dir], colour)
+	}
+}
+
+// PlaceKey leaves a key of the given colour in room (x, y).
+// Icarus picks it up when he enters the room.
+func (m *GridMaze) PlaceKey(x, y int, colour string) error {
+	r, err := m.GetRoom(x, y)
+	if err != nil {
+		return err
+	}
+
+	if r.Excluded || r.Portal || r.Treasure {
+		return errors.New("can't have a key inside rock, a portal or the treasure")
+	}
+
+	r.Key = colour
+	return nil
+}
+
+// Inventory returns the colours of the keys Icarus has picked up
+func (m *GridMaze) Inventory() []string {
+	keys := make([]string, 0, len(m.keys))
+	for k := range m.keys {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// KeyCarver is implemented by mazes that can have keys and locked doors
+type KeyCarver interface {
+	Carver
+	Icarus() (x, y int)
+	GetRoom(x, y int) (*Room, error)
+	Lock(x, y, dir int, colour string)
+	PlaceKey(x, y int, colour string) error
+}
+
+// door is a passage between two rooms, from either side
+type door [2]Coordinate
+
+// PlaceKeys locks up to n doors on the way from Icarus to the treasure,
+// each of a different colour, and hides the key of each door somewhere
+// Icarus can reach with the keys of the doors before it. Icarus must be
+// placed and the maze must be perfect, so that the treasure can only be
+// reached through the locked doors. It returns the number of doors locked.
+func PlaceKeys(m KeyCarver, n int) int {
+	ix, iy := m.Icarus()
+	start := Coordinate{X: ix, Y: iy}
+	path := pathToTreasure(m, start)
+	if n > len(KeyColours) {
+		n = len(KeyColours)
+	}
+	if n > len(path)-1 {
+		n = len(path) - 1
+	}
+	if n <= 0 {
+		return 0
+	}
+
+	picks := rand.Perm(len(path) - 1)[:n]
+	sort.Ints(picks)
+
+	// doors that are still locked when looking for a room for a key
+	locked := make(map[door]bool)
+	for _, p := range picks {
+		locked[door{path[p], path[p+1]}] = true
+		locked[door{path[p+1], path[p]}] = true
+	}
+
+	placed := 0
+	for _, p := range picks {
+		rooms := make([]Coordinate, 0)
+		for _, c := range reachWithout(m, start, locked) {
+			if r, _ := m.GetRoom(c.X, c.Y); c != start && !r.Portal && !r.Treasure && r.Key == "" {
+				rooms = append(rooms, c)
+			}
+		}
+
+		a, b := path[p], path[p+1]
+		delete(locked, door{a, b})
+		delete(locked, door{b, a})
+		if len(rooms) == 0 {
+			continue
+		}
+
+		colour := KeyColours[placed]
+		k := rooms[rand.Intn(len(rooms))]
+		m.PlaceKey(k.X, k.Y, colour)
+		m.Lock(a.X, a.Y, directionTo(m, a, b), colour)
+		placed++
+	}
+	return placed
+}
+
+// directionTo returns the direction from room a to its neighbour b
+func directionTo(m Carver, a, b Coordinate) int {
+	for _, dir := range []int{N, S, E, W} {
+		if next, ok := neighbour(a, dir, m.Width(), m.Height(), m.Wraps()); ok && next == b {
+			return dir
+		}
+	}
+	return 0
+}
+
+// reachWithout returns the rooms that can be reached from start without
+// going through the locked doors
+func reachWithout(m Carver, start Coordinate, locked map[door]bool) []Coordinate {
+	w, h := m.Width(), m.Height()
+	seen := map[Coordinate]bool{start: true}
+	queue := []Coordinate{start}
+	for i := 0; i < len(queue); i++ {
+		cur := queue[i]
+		s, _ := m.Discover(cur.X, cur.Y)
+		for _, dir := range []int{N, S, E, W} {
+			next, ok := neighbour(cur, dir, w, h, m.Wraps())
+			if !ok || wallFacing(s, dir) || seen[next] || locked[door{cur, next}] {
+				continue
+			}
+			seen[next] = true
+			queue = append(queue, next)
+		}
+	}
+	return queue
+}
+
+// pathToTreasure returns the rooms on the shortest way from start to the
+// treasure, both included, or nil if it can't be reached
+func pathToTreasure(m KeyCarver, start Coordinate) []Coordinate {
+	w, h := m.Width(), m.Height()
+	parent := map[Coordinate]Coordinate{start: start}
+	queue := []Coordinate{start}
+	for i := 0; i < len(queue); i++ {
+		cur := queue[i]
+		if r, _ := m.GetRoom(cur.X, cur.Y); r.Treasure {
+			path := []Coordinate{cur}
+			for cur != start {
+				cur = parent[cur]
+				path = append([]Coordinate{cur}, path...)
+			}
+			return path
+		}
+
+		s, _ := m.Discover(cur.X, cur.Y)
+		for _, dir := range []int{N, S, E, W} {
+			next, ok := neighbour(cur, dir, w, h, m.Wraps())
+			if _, seen := parent[next]; !ok || wallFacing(s, dir) || seen {
+				continue
+			}
+			parent[next] = cur
+			queue = append(queue, next)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+// unlock walks from Icarus through every door he has the key of, picking
+// up the keys on the way until no more doors open. It returns the rooms
+// reached and the keys held.
+func unlock(m *GridMaze) (map[Coordinate]bool, map[string]bool) {
+	x, y := m.Icarus()
+	held := make(map[string]bool)
+	for {
+		seen := map[Coordinate]bool{{X: x, Y: y}: true}
+		queue := []Coordinate{{X: x, Y: y}}
+		for i := 0; i < len(queue); i++ {
+			cur := queue[i]
+			s, _ := m.Discover(cur.X, cur.Y)
+			for _, dir := range []int{N, S, E, W} {
+				next, ok := neighbour(cur, dir, m.Width(), m.Height(), m.Wraps())
+				if lock := s.Lock(dir); !ok || wallFacing(s, dir) || seen[next] || lock != "" && !held[lock] {
+					continue
+				}
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+
+		more := false
+		for c := range seen {
+			if r, _ := m.GetRoom(c.X, c.Y); r.Key != "" && !held[r.Key] {
+				held[r.Key] = true
+				more = true
+			}
+		}
+		if !more {
+			return seen, held
+		}
+	}
+}
+
+func TestPlaceKeys(t *testing.T) {
+	// the path through a comb has 14 doors, more than there are colours
+	tests := append(placements(3, 10, len(KeyColours)),
+		placement{"one door", combGrid, 1, 1},
+		// the first door has nowhere before it for its key
+		placement{"corridor", corridor, 5, 1},
+	)
+
+	for _, tt := range tests {
+		m := tt.maze()
+		m.SetStartPoint(0, 0)
+		m.SetTreasure(m.Width()-1, m.Height()-1)
+
+		placed := PlaceKeys(m, tt.n)
+		if placed > tt.most {
+			t.Errorf("%s: %d locked doors, want at most %d", tt.name, placed, tt.most)
+		}
+
+		keys, locks := make(map[string]bool), make(map[string]bool)
+		for y := 0; y < m.Height(); y++ {
+			for x := 0; x < m.Width(); x++ {
+				r, _ := m.GetRoom(x, y)
+				if r.Key != "" {
+					keys[r.Key] = true
+				}
+				for _, dir := range []int{N, S, E, W} {
+					if lock := r.Walls.Lock(dir); lock != "" {
+						locks[lock] = true
+					}
+				}
+			}
+		}
+		if len(keys) != placed || len(locks) != placed {
+			t.Errorf("%s: %d keys and %d colours of locks, want %d", tt.name, len(keys), len(locks), placed)
+		}
+
+		// each key must be picked up before its door is needed
+		seen, held := unlock(m)
+		if len(held) != placed {
+			t.Errorf("%s: only %d of %d keys can be picked up", tt.name, len(held), placed)
+		}
+		if tx, ty := m.Width()-1, m.Height()-1; !seen[Coordinate{X: tx, Y: ty}] {
+			t.Errorf("%s: treasure locked away by %d doors", tt.name, placed)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"errors"
+	"fmt"
+)
+
+// LevelMaze is a maze of several levels of the same size, stacked on top
+// of each other. Stairs join a room to the room directly above or below it.
+// As a MazeI, it shows the level Icarus is on.
+type LevelMaze struct {
+	rooms      [][][]Room // indexed as rooms[z][y][x]
+	start      Coordinate
+	end        Coordinate
+	icarus     Coordinate
+	StepsTaken int
+	CostTaken  int
+}
+
+// NewFullLevels creates a maze with all walls and no stairs
+// Good starting point for subtractive algorithms
+func NewFullLevels(width, height, levels int) *LevelMaze {
+	z := LevelMaze{}
+
+	z.rooms = make([][][]Room, levels)
+	for l := 0; l < levels; l++ {
+		z.rooms[l] = make([][]Room, height)
+		for y := 0; y < height; y++ {
+			z.rooms[l][y] = make([]Room, width)
+			for x := 0; x < width; x++ {
+				z.rooms[l][y][x].Walls = Survey{Top: true, Right: true, Bottom: true, Left: true, Up: true, Down: true}
+			}
+		}
+	}
+
+	return &z
+}
+
+// Levels returns the number of levels of the maze
+func (m *LevelMaze) Levels() int { return len(m.rooms) }
+
+// Width returns width of the maze
+func (m *LevelMaze) Width() int { return len(m.rooms[0][0]) }
+
+// Height returns height of the maze
+func (m *LevelMaze) Height() int { return len(m.rooms[0]) }
+
+// Steps returns the number of steps Icarus has taken
+func (m *LevelMaze) Steps() int { return m.StepsTaken }
+
+// Cost returns the total cost of Icarus's steps, where taking the stairs
+// costs StairsCost
+func (m *LevelMaze) Cost() int { return m.CostTaken }
+
+// GetRoom3 returns the Room at (x, y) on level z
+func (m *LevelMaze) GetRoom3(x, y, z int) (*Room, error) {
+	if x < 0 || y < 0 || z < 0 || x >= m.Width() || y >= m.Height() || z >= m.Levels() {
+		return &Room{}, ErrOutOfBounds
+	}
+
+	return &m.rooms[z][y][x], nil
+}
+
+// GetRoom returns the Room at (x, y) on Icarus's level
+func (m *LevelMaze) GetRoom(x, y int) (*Room, error) {
+	return m.GetRoom3(x, y, m.icarus.Z)
+}
+
+// Icarus returns the finder's current position on his level
+func (m *LevelMaze) Icarus() (x, y int) {
+	return m.icarus.X, m.icarus.Y
+}
+
+// Icarus3 returns the finder's current position and level
+func (m *LevelMaze) Icarus3() (x, y, z int) {
+	return m.icarus.X, m.icarus.Y, m.icarus.Z
+}
+
+// SetStartPoint3 sets the location and level where Icarus will awake
+func (m *LevelMaze) SetStartPoint3(x, y, z int) error {
+	r, err := m.GetRoom3(x, y, z)
+
+	if err != nil {
+		return err
+	}
+
+	if r.Treasure {
+		return errors.New("can't start in the treasure")
+	}
+
+	r.Start = true
+	m.start = Coordinate{X: x, Y: y, Z: z}
+	m.icarus = m.start
+	return nil
+}
+
+// SetStartPoint sets the location where Icarus will awake, on his current level
+func (m *LevelMaze) SetStartPoint(x, y int) error {
+	return m.SetStartPoint3(x, y, m.icarus.Z)
+}
+
+// SetTreasure3 sets the location and level of the treasure
+func (m *LevelMaze) SetTreasure3(x, y, z int) error {
+	r, err := m.GetRoom3(x, y, z)
+
+	if err != nil {
+		return err
+	}
+
+	if r.Start {
+		return errors.New("can't have the treasure at the start")
+	}
+
+	r.Treasure = true
+	m.end = Coordinate{X: x, Y: y, Z: z}
+	return nil
+}
+
+// SetTreasure sets the location of the treasure, on Icarus's current level
+func (m *LevelMaze) SetTreasure(x, y int) error {
+	return m.SetTreasure3(x, y, m.icarus.Z)
+}
+
+// LookAround Given Icarus's current location, Discover that room
+// Will return ErrVictory if Icarus is at the treasure.
+func (m *LevelMaze) LookAround() (Survey, error) {
+	if m.end == m.icarus {
+		return Survey{}, ErrVictory
+	}
+
+	return m.Discover3(m.icarus.X, m.icarus.Y, m.icarus.Z)
+}
+
+// Discover3 Given a location and level, survey the room.
+// Will return error if it is outside of the maze
+func (m *LevelMaze) Discover3(x, y, z int) (Survey, error) {
+	r, err := m.GetRoom3(x, y, z)
+	if err != nil {
+		return Survey{}, err
+	}
+	return r.Walls, nil
+}
+
+// Discover Given two points, survey the room on Icarus's level.
+// Will return error if two points are outside of the maze
+func (m *LevelMaze) Discover(x, y int) (Survey, error) {
+	return m.Discover3(x, y, m.icarus.Z)
+}
+
+// move Moves Icarus's position one step in the given direction
+// Will not permit moving through walls or out of the maze
+func (m *LevelMaze) move(dir int) error {
+	s, e := m.LookAround()
+	if e != nil {
+		return e
+	}
+	if wallFacing(s, dir) {
+		return ErrWall
+	}
+
+	next := Coordinate{X: m.icarus.X + Delta[dir].X, Y: m.icarus.Y + Delta[dir].Y, Z: m.icarus.Z + Delta[dir].Z}
+	if _, err := m.GetRoom3(next.X, next.Y, next.Z); err != nil {
+		return err
+	}
+
+	m.icarus = next
+	m.StepsTaken++
+	m.CostTaken += s.Cost(dir)
+	return nil
+}
+
+// MoveLeft Moves Icarus's position left one step
+func (m *LevelMaze) MoveLeft() error { return m.move(W) }
+
+// MoveRight Moves Icarus's position right one step
+func (m *LevelMaze) MoveRight() error { return m.move(E) }
+
+// MoveUp Moves Icarus's position up one step
+func (m *LevelMaze) MoveUp() error { return m.move(N) }
+
+// MoveDown Moves Icarus's position down one step
+func (m *LevelMaze) MoveDown() error { return m.move(S) }
+
+// MoveAbove Moves Icarus's position up the stairs to the level above
+func (m *LevelMaze) MoveAbove() error { return m.move(U) }
+
+// MoveBelow Moves Icarus's position down the stairs to the level below
+func (m *LevelMaze) MoveBelow() error { return m.move(D) }
+
+// AddWall3 adds a wall to room (x, y) on level z and the matching wall to its neighbour
+func (m *LevelMaze) AddWall3(x, y, z, dir int) {
+	if r, err := m.GetRoom3(x, y, z); err == nil {
+		r.AddWall(dir)
+	}
+	if r, err := m.GetRoom3(x+Delta[dir].X, y+Delta[dir].Y, z+Delta[dir].Z); err == nil {
+		r.AddWall(Opposite[dir])
+	}
+}
+
+// RmWall3 removes a wall from room (x, y) on level z and the matching wall from its neighbour
+func (m *LevelMaze) RmWall3(x, y, z, dir int) {
+	if r, err := m.GetRoom3(x, y, z); err == nil {
+		r.RmWall(dir)
+	}
+	if r, err := m.GetRoom3(x+Delta[dir].X, y+Delta[dir].Y, z+Delta[dir].Z); err == nil {
+		r.RmWall(Opposite[dir])
+	}
+}
+
+// Level returns a single level of the maze, useful to print or
+// validate it. Icarus is only shown on his own level.
+func (m *LevelMaze) Level(z int) MazeI {
+	return &level{m, z}
+}
+
+// level is a view of one level of a LevelMaze
+type level struct {
+	*LevelMaze
+	z int
+}
+
+func (l *level) GetRoom(x, y int) (*Room, error) { return l.GetRoom3(x, y, l.z) }
+
+func (l *level) Discover(x, y int) (Survey, error) { return l.Discover3(x, y, l.z) }
+
+func (l *level) Icarus() (x, y int) {
+	if l.icarus.Z != l.z {
+		return -1, -1
+	}
+	return l.icarus.X, l.icarus.Y
+}
+
+// PrintLevels prints every level of the maze, from the bottom up
+func PrintLevels(m *LevelMaze) {
+	for z := 0; z < m.Levels(); z++ {
+		fmt.Printf("Level %d\n", z)
+		PrintMaze(m.Level(z))
+	}
+}
@@ -0,0 +1,75 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+func TestLevelMove(t *testing.T) {
+	// a 2 x 2 maze of 2 levels, with Icarus at (0, 0) on the bottom level
+	// and the treasure at (1, 1) on the top one
+	tests := []struct {
+		name     string
+		open     []int
+		moves    []int
+		err      error
+		at       Coordinate
+		steps    int
+		cost     int
+		treasure bool
+	}{
+		{"along the level", []int{E}, []int{E}, nil, Coordinate{X: 1}, 1, 1, false},
+		{"up the stairs", []int{U}, []int{U}, nil, Coordinate{Z: 1}, 1, StairsCost, false},
+		{"up and back down", []int{U}, []int{U, D}, nil, Coordinate{}, 2, 2 * StairsCost, false},
+		{"no stairs", nil, []int{U}, ErrWall, Coordinate{}, 0, 0, false},
+		{"below the bottom", []int{D}, []int{D}, ErrOutOfBounds, Coordinate{}, 0, 0, false},
+		{"onto the treasure", []int{U}, []int{U, E, S}, nil, Coordinate{X: 1, Y: 1, Z: 1}, 3, StairsCost + 2, true},
+	}
+
+	for _, tt := range tests {
+		m := NewFullLevels(2, 2, 2)
+		m.SetStartPoint3(0, 0, 0)
+		m.SetTreasure3(1, 1, 1)
+		for _, dir := range tt.open {
+			m.RmWall3(0, 0, 0, dir)
+		}
+		// the top level is open, so the treasure is a walk from the stairs
+		m.RmWall3(0, 0, 1, E)
+		m.RmWall3(1, 0, 1, S)
+
+		var err error
+		for _, dir := range tt.moves {
+			if err = m.move(dir); err != nil {
+				break
+			}
+		}
+		if x, y, z := m.Icarus3(); err != tt.err || (Coordinate{X: x, Y: y, Z: z}) != tt.at {
+			t.Errorf("%s: moved to (%d, %d, %d) with %v, want %v with %v", tt.name, x, y, z, err, tt.at, tt.err)
+		}
+		if m.Steps() != tt.steps || m.Cost() != tt.cost {
+			t.Errorf("%s: %d steps costing %d, want %d costing %d", tt.name, m.Steps(), m.Cost(), tt.steps, tt.cost)
+		}
+		if _, err := m.LookAround(); (err == ErrVictory) != tt.treasure {
+			t.Errorf("%s: LookAround gave %v, want victory %t", tt.name, err, tt.treasure)
+		}
+	}
+}
+
+func TestLevel(t *testing.T) {
+	m := NewFullLevels(2, 2, 3)
+	m.SetStartPoint3(1, 0, 1)
+	m.RmWall3(0, 0, 2, E)
+
+	for z := 0; z < m.Levels(); z++ {
+		l := m.Level(z)
+		x, y := l.Icarus()
+		if here := z == 1; here != (x == 1 && y == 0) || !here && (x != -1 || y != -1) {
+			t.Errorf("level %d: Icarus at (%d, %d)", z, x, y)
+		}
+		s, _ := l.Discover(0, 0)
+		if open := z == 2; s.Right == open {
+			t.Errorf("level %d: wall to the right of (0, 0) is %t", z, s.Right)
+		}
+	}
+}
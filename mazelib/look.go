@@ -0,0 +1,71 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+// Look is the step a solver takes to look down the corridors instead of
+// moving. It is answered with a MazeReply with the Sight.
+const Look = Outward + maxOutward
+
+// Sight is what Icarus sees looking down the corridors from his room:
+// how many rooms there are in each direction before a wall, and the move
+// towards the nearest treasure, and how far it is, if he can see one.
+// Locked doors block the view like walls.
+type Sight struct {
+	Top      int    `json:"top"`
+	Right    int    `json:"right"`
+	Bottom   int    `json:"bottom"`
+	Left     int    `json:"left"`
+	Treasure string `json:"treasure,omitempty"`
+	Distance int    `json:"distance,omitempty"`
+}
+
+// Towards returns the direction of the treasure and how many steps away
+// it is, or 0, 0 if it can't be seen
+func (s Sight) Towards() (dir, rooms int) {
+	for d, name := range SquareMoves {
+		if s.Treasure != "" && name == s.Treasure {
+			return d, s.Distance
+		}
+	}
+	return 0, 0
+}
+
+// LineOfSight looks down the straight corridors from room (x, y)
+// on a maze with a single level, or on Icarus's level
+func LineOfSight(m MazeI, x, y int) Sight {
+	var s Sight
+	w, h := m.Width(), m.Height()
+	torus := isTorus(m)
+	for _, dir := range []int{N, E, S, W} {
+		cur := Coordinate{X: x, Y: y}
+		n := 0
+		for n < w+h {
+			survey, err := m.Discover(cur.X, cur.Y)
+			next, ok := neighbour(cur, dir, w, h, torus)
+			if err != nil || !ok || wallFacing(survey, dir) || survey.Lock(dir) != "" || roomExcluded(m, next.X, next.Y) {
+				break
+			}
+			if next == (Coordinate{X: x, Y: y}) {
+				// all the way round a torus
+				break
+			}
+			cur = next
+			n++
+			if r, err := m.GetRoom(cur.X, cur.Y); err == nil && r.Treasure && (s.Treasure == "" || n < s.Distance) {
+				s.Treasure, s.Distance = SquareMoves[dir], n
+			}
+		}
+
+		switch dir {
+		case N:
+			s.Top = n
+		case E:
+			s.Right = n
+		case S:
+			s.Bottom = n
+		case W:
+			s.Left = n
+		}
+	}
+	return s
+}
@@ -0,0 +1,69 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+func TestLineOfSight(t *testing.T) {
+	// the treasure is at the bottom of the last tooth of a comb
+	comb := func() *GridMaze {
+		m := combGrid()
+		m.SetStartPoint(0, 0)
+		m.SetTreasure(7, 7)
+		return m
+	}
+
+	tests := []struct {
+		name string
+		maze func() *GridMaze
+		x, y int
+		want Sight
+	}{
+		{"corner", comb, 0, 0, Sight{Right: 7, Bottom: 7}},
+		{"in a tooth", comb, 3, 4, Sight{Top: 4, Bottom: 3}},
+		{"down to the treasure", comb, 7, 0, Sight{Bottom: 7, Left: 7, Treasure: "down", Distance: 7}},
+		{"rock", func() *GridMaze {
+			m := comb()
+			m.Exclude(3, 7)
+			return m
+		}, 3, 0, Sight{Right: 4, Bottom: 6, Left: 3}},
+		{"locked door", func() *GridMaze {
+			m := comb()
+			m.Lock(7, 3, S, "red")
+			return m
+		}, 7, 0, Sight{Bottom: 3, Left: 7}},
+		// the nearest treasure either way round is to the right, seen first
+		{"round a torus", func() *GridMaze {
+			m := NewEmptyTorus(4, 1)
+			m.SetStartPoint(0, 0)
+			m.SetTreasure(2, 0)
+			return m
+		}, 0, 0, Sight{Right: 3, Left: 3, Treasure: "right", Distance: 2}},
+	}
+
+	for _, tt := range tests {
+		if got := LineOfSight(tt.maze(), tt.x, tt.y); got != tt.want {
+			t.Errorf("%s: sees %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTowards(t *testing.T) {
+	tests := []struct {
+		s     Sight
+		dir   int
+		rooms int
+	}{
+		{Sight{Right: 4, Treasure: "right", Distance: 3}, E, 3},
+		{Sight{Top: 2, Treasure: "up", Distance: 2}, N, 2},
+		{Sight{Top: 2, Bottom: 5}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		if dir, rooms := tt.s.Towards(); dir != tt.dir || rooms != tt.rooms {
+			t.Errorf("%+v: Towards is %d, %d, want %d, %d", tt.s, dir, rooms, tt.dir, tt.rooms)
+		}
+	}
+}
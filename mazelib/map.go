@@ -0,0 +1,147 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+// Map is the whole of a maze with a single level, as Daedalus sees it.
+// Rooms are by row, then column.
+type Map struct {
+	Width     int                   `json:"width"`
+	Height    int                   `json:"height"`
+	Rooms     [][]Survey            `json:"rooms"`
+	Icarus    Coordinate            `json:"icarus"`
+	Treasures []Coordinate          `json:"treasures"`
+	Rock      []Coordinate          `json:"rock,omitempty"`
+	Portals   []Coordinate          `json:"portals,omitempty"`
+	Keys      map[string]Coordinate `json:"keys,omitempty"`
+	Optimum   int                   `json:"optimum"`
+}
+
+// MapOf draws up the map of the maze, with the fewest steps Icarus
+// needs from where he is, see Optimum
+func MapOf(m MazeI) Map {
+	w, h := m.Width(), m.Height()
+	ix, iy := m.Icarus()
+	mp := Map{Width: w, Height: h, Rooms: make([][]Survey, h), Icarus: Coordinate{X: ix, Y: iy},
+		Treasures: treasureRooms(m), Optimum: Optimum(m)}
+
+	for y := 0; y < h; y++ {
+		mp.Rooms[y] = make([]Survey, w)
+		for x := 0; x < w; x++ {
+			r, err := m.GetRoom(x, y)
+			if err != nil {
+				continue
+			}
+			mp.Rooms[y][x] = r.Walls
+			c := Coordinate{X: x, Y: y}
+			switch {
+			case r.Excluded:
+				mp.Rock = append(mp.Rock, c)
+			case r.Portal:
+				mp.Portals = append(mp.Portals, c)
+			case r.Key != "":
+				if mp.Keys == nil {
+					mp.Keys = make(map[string]Coordinate)
+				}
+				mp.Keys[r.Key] = c
+			}
+		}
+	}
+	return mp
+}
+
+// treasureRooms returns the rooms with a treasure in them
+func treasureRooms(m MazeI) []Coordinate {
+	rooms := make([]Coordinate, 0, 1)
+	for y := 0; y < m.Height(); y++ {
+		for x := 0; x < m.Width(); x++ {
+			if r, err := m.GetRoom(x, y); err == nil && r.Treasure {
+				rooms = append(rooms, Coordinate{X: x, Y: y})
+			}
+		}
+	}
+	return rooms
+}
+
+// maxExact is the most treasures Optimum finds the best order for.
+// With more, it goes to the nearest treasure each time.
+const maxExact = 12
+
+// Optimum returns the fewest steps Icarus needs from where he is to pick
+// up every treasure in a maze with a single level, or -1 if he can't
+// reach them all. It walks through locked doors and doesn't take portals,
+// so with keys it needs more, and with portals it may need less.
+func Optimum(m MazeI) int {
+	ix, iy := m.Icarus()
+	treasures := treasureRooms(m)
+	n := len(treasures)
+	if n == 0 {
+		return -1
+	}
+
+	// steps from Icarus, and from each treasure
+	from := make([]map[Coordinate]int, n+1)
+	from[0] = distances(m, Coordinate{X: ix, Y: iy}, -1)
+	for i, t := range treasures {
+		if _, ok := from[0][t]; !ok {
+			return -1
+		}
+		from[i+1] = distances(m, t, -1)
+	}
+
+	if n > maxExact {
+		steps, at := 0, 0
+		left := make(map[int]bool)
+		for i := 0; i < n; i++ {
+			left[i] = true
+		}
+		for len(left) > 0 {
+			next := -1
+			for i := range left {
+				if next < 0 || from[at][treasures[i]] < from[at][treasures[next]] {
+					next = i
+				}
+			}
+			steps += from[at][treasures[next]]
+			delete(left, next)
+			at = next + 1
+		}
+		return steps
+	}
+
+	// best[set][i] is the fewest steps to pick up the set of treasures,
+	// the i-th last, as Held and Karp would
+	best := make([][]int, 1<<uint(n))
+	for set := range best {
+		best[set] = make([]int, n)
+		for i := range best[set] {
+			best[set][i] = -1
+		}
+	}
+	for i, t := range treasures {
+		best[1<<uint(i)][i] = from[0][t]
+	}
+	for set := 1; set < len(best); set++ {
+		for i := 0; i < n; i++ {
+			if best[set][i] < 0 {
+				continue
+			}
+			for j, t := range treasures {
+				next := set | 1<<uint(j)
+				if next == set {
+					continue
+				}
+				if d := best[set][i] + from[i+1][t]; best[next][j] < 0 || d < best[next][j] {
+					best[next][j] = d
+				}
+			}
+		}
+	}
+
+	fewest := -1
+	for _, d := range best[len(best)-1] {
+		if d >= 0 && (fewest < 0 || d < fewest) {
+			fewest = d
+		}
+	}
+	return fewest
+}
@@ -0,0 +1,110 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// scatter puts n treasures in random rooms of m, away from Icarus at (0, 0)
+func scatter(m *GridMaze, n int) *GridMaze {
+	m.SetStartPoint(0, 0)
+	if n == 0 {
+		return m
+	}
+	for m.SetTreasure(rand.Intn(m.Width()), rand.Intn(m.Height())) != nil {
+	}
+	for placed := 1; placed < n; {
+		if m.AddTreasure(rand.Intn(m.Width()), rand.Intn(m.Height())) == nil {
+			placed++
+		}
+	}
+	return m
+}
+
+// bruteForce returns the fewest steps to pick up every treasure, trying
+// each order in turn, or -1 if one can't be reached
+func bruteForce(m *GridMaze) int {
+	x, y := m.Icarus()
+	stops := append([]Coordinate{{X: x, Y: y}}, treasureRooms(m)...)
+	if len(stops) == 1 {
+		return -1
+	}
+
+	steps := make([]map[Coordinate]int, len(stops))
+	for i, c := range stops {
+		steps[i] = map[Coordinate]int{c: 0}
+		queue := []Coordinate{c}
+		for j := 0; j < len(queue); j++ {
+			cur := queue[j]
+			s, _ := m.Discover(cur.X, cur.Y)
+			for _, dir := range []int{N, S, E, W} {
+				next, ok := neighbour(cur, dir, m.Width(), m.Height(), m.Wraps())
+				if _, seen := steps[i][next]; !ok || wallFacing(s, dir) || seen {
+					continue
+				}
+				steps[i][next] = steps[i][cur] + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	fewest := -1
+	var try func(at int, left []int, total int)
+	try = func(at int, left []int, total int) {
+		if len(left) == 0 {
+			if fewest < 0 || total < fewest {
+				fewest = total
+			}
+			return
+		}
+		for i, next := range left {
+			d, ok := steps[at][stops[next]]
+			if !ok {
+				continue
+			}
+			rest := append(append([]int{}, left[:i]...), left[i+1:]...)
+			try(next, rest, total+d)
+		}
+	}
+	left := make([]int, len(stops)-1)
+	for i := range left {
+		left[i] = i + 1
+	}
+	try(0, left, 0)
+	return fewest
+}
+
+func TestOptimum(t *testing.T) {
+	tests := []struct {
+		name      string
+		maze      func() *GridMaze
+		treasures int
+	}{
+		{"no treasure", combGrid, 0},
+		// the corridor has its own treasure
+		{"corridor", corridor, 0},
+		{"one treasure", combGrid, 1},
+		{"three treasures", combGrid, 3},
+		{"six treasures", combGrid, 6},
+		{"loops", func() *GridMaze { return NewEmptyGrid(5, 5) }, 5},
+		{"torus", combTorus, 4},
+		{"walled off", func() *GridMaze {
+			m := scatter(NewEmptyGrid(5, 5), 3)
+			m.AddWall(4, 4, N)
+			m.AddWall(4, 4, W)
+			m.AddTreasure(4, 4)
+			return m
+		}, 0},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			m := scatter(tt.maze(), tt.treasures)
+			if got, want := Optimum(m), bruteForce(m); got != want {
+				t.Fatalf("%s: Optimum is %d, brute force found %d", tt.name, got, want)
+			}
+		}
+	}
+}
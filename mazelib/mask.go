@@ -0,0 +1,240 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"bufio"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Mask gives the shape of a maze. Mask[y][x] is true if room (x, y)
+// is part of the maze, otherwise the room is solid rock.
+type Mask [][]bool
+
+// Width returns width of the mask
+func (k Mask) Width() int {
+	if len(k) == 0 {
+		return 0
+	}
+	return len(k[0])
+}
+
+// Height returns height of the mask
+func (k Mask) Height() int { return len(k) }
+
+// Included returns if room (x, y) is part of the maze
+func (k Mask) Included(x, y int) bool {
+	return y >= 0 && y < len(k) && x >= 0 && x < len(k[y]) && k[y][x]
+}
+
+// LoadMask reads a mask from a PNG or a text file.
+// In a PNG, dark opaque pixels are part of the maze, one pixel per room.
+// In a text file, every line is a row of rooms. Spaces and dots are
+// rock, any other character is part of the maze.
+func LoadMask(path string) (Mask, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var k Mask
+	if strings.ToLower(filepath.Ext(path)) == ".png" {
+		img, err := png.Decode(f)
+		if err != nil {
+			return nil, err
+		}
+		k = maskFromImage(img)
+	} else {
+		k, err = maskFromText(f)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if k.Width() == 0 || k.Height() == 0 {
+		return nil, errors.New("mask is empty")
+	}
+	if !k.hasPassage() {
+		return nil, errors.New("mask needs 2 rooms next to each other, for Icarus and the treasure")
+	}
+	return k, nil
+}
+
+// hasPassage returns if 2 rooms of the mask are next to each other, so
+// that some part of the maze has room for both Icarus and the treasure
+func (k Mask) hasPassage() bool {
+	for y := range k {
+		for x := range k[y] {
+			if k[y][x] && (k.Included(x+1, y) || k.Included(x, y+1)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func maskFromText(f io.Reader) (Mask, error) {
+	lines := make([]string, 0)
+	width := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := []rune(strings.TrimRight(scanner.Text(), "\r"))
+		if len(line) > width {
+			width = len(line)
+		}
+		lines = append(lines, string(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// drop empty lines at the end of the file
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	k := make(Mask, len(lines))
+	for y, line := range lines {
+		k[y] = make([]bool, width)
+		for x, c := range []rune(line) {
+			k[y][x] = c != ' ' && c != '.'
+		}
+	}
+	return k, nil
+}
+
+func maskFromImage(img image.Image) Mask {
+	b := img.Bounds()
+	k := make(Mask, b.Dy())
+	for y := 0; y < b.Dy(); y++ {
+		k[y] = make([]bool, b.Dx())
+		for x := 0; x < b.Dx(); x++ {
+			r, g, bl, a := img.At(b.Min.X+x, b.Min.Y+y).RGBA()
+			luma := (299*r + 587*g + 114*bl) / 1000
+			k[y][x] = a >= 0x8000 && luma < 0x8000
+		}
+	}
+	return k
+}
+
+// ApplyMask turns every room outside of the mask into solid rock
+func ApplyMask(m Carver, k Mask) {
+	for y := 0; y < m.Height(); y++ {
+		for x := 0; x < m.Width(); x++ {
+			if !k.Included(x, y) {
+				m.Exclude(x, y)
+			}
+		}
+	}
+}
+
+// JoinRegions removes walls between neighbouring regions until every
+// room that is not rock can be reached from every other room that the
+// shape allows. Joining regions of a perfect maze keeps it perfect.
+// Regions are only joined across the inside of the maze, even on a torus.
+func JoinRegions(m Carver) {
+	w, h := m.Width(), m.Height()
+
+	rock := make([][]bool, h)
+	for y := 0; y < h; y++ {
+		rock[y] = make([]bool, w)
+		for x := 0; x < w; x++ {
+			rock[y][x] = m.Excluded(x, y)
+		}
+	}
+	label, regions := labelRegions(m)
+
+	// walls between rooms of different regions, in random order
+	type edge struct {
+		x, y, dir int
+	}
+	edges := make([]edge, 0)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if rock[y][x] {
+				continue
+			}
+			if x < w-1 && !rock[y][x+1] && label[y*w+x] != label[y*w+x+1] {
+				edges = append(edges, edge{x, y, E})
+			}
+			if y < h-1 && !rock[y+1][x] && label[y*w+x] != label[(y+1)*w+x] {
+				edges = append(edges, edge{x, y, S})
+			}
+		}
+	}
+	for i := range edges {
+		j := rand.Intn(i + 1)
+		edges[i], edges[j] = edges[j], edges[i]
+	}
+
+	sets := NewSets(regions + 1)
+
+	for _, e := range edges {
+		nx, ny := e.x+Delta[e.dir].X, e.y+Delta[e.dir].Y
+		if sets.Union(label[e.y*w+e.x], label[ny*w+nx]) {
+			m.RmWall(e.x, e.y, e.dir)
+		}
+	}
+}
+
+// labelRegions labels every room with the region it is in, numbered from
+// 1, and returns the labels by y*width+x and the number of regions.
+// Rock is labelled 0.
+func labelRegions(m Carver) ([]int, int) {
+	w, h := m.Width(), m.Height()
+
+	surveys := make([][]Survey, h)
+	for y := 0; y < h; y++ {
+		surveys[y] = make([]Survey, w)
+		for x := 0; x < w; x++ {
+			surveys[y][x], _ = m.Discover(x, y)
+		}
+	}
+
+	label := make([]int, w*h)
+	regions := 0
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if m.Excluded(x, y) || label[y*w+x] != 0 {
+				continue
+			}
+			regions++
+			seen := make(map[Coordinate]bool)
+			flood(surveys, Coordinate{X: x, Y: y}, seen, true, m.Wraps())
+			for c := range seen {
+				label[c.Y*w+c.X] = regions
+			}
+		}
+	}
+	return label, regions
+}
+
+// Reachable returns every room Icarus can walk to from room (x, y),
+// including (x, y) itself
+func Reachable(m MazeI, x, y int) []Coordinate {
+	w, h := m.Width(), m.Height()
+	surveys := make([][]Survey, h)
+	for j := 0; j < h; j++ {
+		surveys[j] = make([]Survey, w)
+		for i := 0; i < w; i++ {
+			surveys[j][i], _ = m.Discover(i, j)
+		}
+	}
+
+	seen := make(map[Coordinate]bool)
+	flood(surveys, Coordinate{X: x, Y: y}, seen, false, isTorus(m))
+
+	rooms := make([]Coordinate, 0, len(seen))
+	for c := range seen {
+		rooms = append(rooms, c)
+	}
+	return rooms
+}
@@ -0,0 +1,44 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadMask(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		ok            bool
+		width, height int
+	}{
+		{"row", "##\n", true, 2, 1},
+		{"column", "#\n#\n", true, 1, 2},
+		{"ring", "###\n#.#\n###\n", true, 3, 3},
+		{"ragged", "#\n###\n\n\n", true, 3, 2},
+		{"one room", "#\n", false, 0, 0},
+		{"checkerboard", "#.#\n.#.\n#.#\n", false, 0, 0},
+		{"diagonal", "#.\n.#\n", false, 0, 0},
+		{"rock", "...\n   \n", false, 0, 0},
+		{"empty", "", false, 0, 0},
+	}
+
+	dir := t.TempDir()
+	for _, tt := range tests {
+		path := filepath.Join(dir, tt.name+".txt")
+		if err := os.WriteFile(path, []byte(tt.text), 0644); err != nil {
+			t.Fatal(err)
+		}
+		k, err := LoadMask(path)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: error %v, want ok %t", tt.name, err, tt.ok)
+			continue
+		}
+		if tt.ok && (k.Width() != tt.width || k.Height() != tt.height) {
+			t.Errorf("%s: mask is %d x %d, want %d x %d", tt.name, k.Width(), k.Height(), tt.width, tt.height)
+		}
+	}
+}
@@ -23,48 +23,131 @@ import (
 	"fmt"
 	"math/rand"
 	"os"
-	"strings"
 )
 
 // Coordinate describes a location in the maze
+// Z is the level, and is always 0 for a maze with a single level.
 type Coordinate struct {
 	X int `json:"x"`
 	Y int `json:"y"`
+	Z int `json:"z,omitempty"`
 }
 
 // Reply from the server to a request
+// Hex is only set for hexagonal mazes, Polar and Back for polar mazes,
+// and Exits and Back for mazes served as a graph.
+// Remaining and PickedUp are only set when there are several treasures.
+// Minotaur is the move towards the Minotaur when it is near.
+// Code tells what went wrong when there is an Error, see ErrorCode.
+// Sight is only set in reply to /look.
+// Hint is how close the treasure is, hot, warm or cold, when hints are on.
+// Width and Height are only set in reply to /awake, and Position in GPS mode.
+// Modes are only set in reply to /awake, see ModeOneWay, ModeLook and ModeTorus.
 type Reply struct {
-	Survey  Survey `json:"survey"`
-	Victory bool   `json:"victory"`
-	Message string `json:"message"`
-	Error   bool   `json:"error"`
+	Survey     Survey       `json:"survey"`
+	Hex        *HexSurvey   `json:"hex,omitempty"`
+	Polar      *PolarSurvey `json:"polar,omitempty"`
+	Exits      *ExitSurvey  `json:"exits,omitempty"`
+	Back       string       `json:"back,omitempty"`
+	Teleported bool         `json:"teleported,omitempty"`
+	Shifted    bool         `json:"shifted,omitempty"`
+	Keys       []string     `json:"keys,omitempty"`
+	Remaining  int          `json:"remaining,omitempty"`
+	PickedUp   bool         `json:"pickedUp,omitempty"`
+	Minotaur   string       `json:"minotaur,omitempty"`
+	Caught     bool         `json:"caught,omitempty"`
+	Code       string       `json:"code,omitempty"`
+	Sight      *Sight       `json:"sight,omitempty"`
+	Hint       string       `json:"hint,omitempty"`
+	Width      int          `json:"width,omitempty"`
+	Height     int          `json:"height,omitempty"`
+	Position   *Coordinate  `json:"position,omitempty"`
+	Modes      []string     `json:"modes,omitempty"`
+	Victory    bool         `json:"victory"`
+	Message    string       `json:"message"`
+	Error      bool         `json:"error"`
+}
+
+// Modes of a maze that Daedalus tells Icarus of when he wakes up, so he
+// can pick a solver that works in it
+const (
+	// ModeOneWay is a maze with one way doors, see FindTreasureDirected
+	ModeOneWay = "one-way"
+	// ModeLook is a maze where Icarus should look down the corridors,
+	// see FindTreasureLooking
+	ModeLook = "look"
+	// ModeTorus is a maze that wraps around at the edges, Daedalus tells
+	// its Width and Height with it
+	ModeTorus = "torus"
+)
+
+// HasMode returns if the reply tells of the mode
+func (r Reply) HasMode(mode string) bool {
+	for _, m := range r.Modes {
+		if m == mode {
+			return true
+		}
+	}
+	return false
 }
 
 // Survey Given a location, survey surrounding locations
 // True indicates a wall is present.
+// Up and Down are false where stairs lead to the level above or below.
+// The costs are the cost of entering the room on each side, they are
+// only set when the maze has terrain, see Survey.Cost. The locks are the
+// colour of the key a door on each side needs, see Survey.Lock.
 type Survey struct {
 	Top    bool `json:"top"`
 	Right  bool `json:"right"`
 	Bottom bool `json:"bottom"`
 	Left   bool `json:"left"`
+	Up     bool `json:"up"`
+	Down   bool `json:"down"`
+
+	TopCost    int `json:"topCost,omitempty"`
+	RightCost  int `json:"rightCost,omitempty"`
+	BottomCost int `json:"bottomCost,omitempty"`
+	LeftCost   int `json:"leftCost,omitempty"`
+
+	TopLock    string `json:"topLock,omitempty"`
+	RightLock  string `json:"rightLock,omitempty"`
+	BottomLock string `json:"bottomLock,omitempty"`
+	LeftLock   string `json:"leftLock,omitempty"`
 }
 
 // N, S, E, W directions corresponds to Top, Bottom, Right, Left
+// U, D directions corresponds to Up, Down
+// NE, SE, SW, NW are the diagonal directions of a hexagonal maze,
+// which has N and S but no E and W
 const (
-	N = 1
-	S = 2
-	E = 3
-	W = 4
+	N  = 1
+	S  = 2
+	E  = 3
+	W  = 4
+	U  = 5
+	D  = 6
+	NE = 7
+	SE = 8
+	SW = 9
+	NW = 10
 )
 
 // ErrVictory indicates success in solving the maze
 var ErrVictory = errors.New("Victory")
 
 // Room contains the minimum informaion about a room in the maze.
+// An Excluded room is solid rock outside the shape of the maze.
+// A Portal room takes Icarus to another room, see GridMaze.Link.
+// Key is the colour of the key lying in the room, if any.
 type Room struct {
 	Treasure bool
 	Start    bool
 	Visited  bool
+	Excluded bool
+	Portal   bool
+	Key      string
+	Terrain  Terrain
 	Walls    Survey
 }
 
@@ -79,6 +162,10 @@ func (r *Room) AddWall(dir int) {
 		r.Walls.Right = true
 	case W:
 		r.Walls.Left = true
+	case U:
+		r.Walls.Up = true
+	case D:
+		r.Walls.Down = true
 	}
 }
 
@@ -93,6 +180,10 @@ func (r *Room) RmWall(dir int) {
 		r.Walls.Right = false
 	case W:
 		r.Walls.Left = false
+	case U:
+		r.Walls.Up = false
+	case D:
+		r.Walls.Down = false
 	}
 }
 
@@ -110,6 +201,34 @@ type MazeI interface {
 	MoveRight() error
 	MoveUp() error
 	MoveDown() error
+	MoveAbove() error
+	MoveBelow() error
+}
+
+// Carver is implemented by mazes that generators can write to.
+// AddWall and RmWall change the wall shared by a room and its neighbour,
+// so both sides always agree. Exclude turns a room into solid rock.
+type Carver interface {
+	Wrapper
+	Width() int
+	Height() int
+	Discover(x, y int) (Survey, error)
+	AddWall(x, y, dir int)
+	RmWall(x, y, dir int)
+	Exclude(x, y int)
+	Excluded(x, y int) bool
+}
+
+// Wrapper is implemented by mazes that can be a torus. Walking off one
+// edge of a torus brings Icarus in on the opposite edge.
+type Wrapper interface {
+	Wraps() bool
+}
+
+// isTorus returns if the maze wraps around at the edges
+func isTorus(m interface{}) bool {
+	t, ok := m.(Wrapper)
+	return ok && t.Wraps()
 }
 
 // AvgScores is a utility method to compute the average steps
@@ -129,12 +248,27 @@ func AvgScores(in []int) int {
 // PrintMaze : Function to Print Maze to Console
 func PrintMaze(m MazeI) {
 	ix, iy := m.Icarus()
-	fmt.Println("_" + strings.Repeat("___", m.Width()))
+
+	// the top edge, open where a torus wraps around
+	str := "_"
+	for x := 0; x < m.Width(); x++ {
+		if s, err := m.Discover(x, 0); err == nil && !s.Top {
+			str += "  _"
+		} else {
+			str += "___"
+		}
+	}
+	fmt.Println(str)
+
 	for y := 0; y < m.Height(); y++ {
 		str := ""
 		for x := 0; x < m.Width(); x++ {
 			if x == 0 {
-				str += "|"
+				if s, err := m.Discover(x, y); err == nil && !s.Left {
+					str += " "
+				} else {
+					str += "|"
+				}
 			}
 			r, err := m.GetRoom(x, y)
 			if err != nil {
@@ -147,12 +281,14 @@ func PrintMaze(m MazeI) {
 				os.Exit(-1)
 			}
 			if s.Bottom {
-				if r.Treasure {
+				if r.Excluded {
+					str += "##"
+				} else if r.Treasure {
 					str += "⏅_"
 				} else if x == ix && y == iy {
 					str += "⏂_"
 				} else {
-					str += "__"
+					str += ground(r, s, "_")
 				}
 			} else {
 				if r.Treasure {
@@ -160,11 +296,13 @@ func PrintMaze(m MazeI) {
 				} else if x == ix && y == iy {
 					str += "⏀ "
 				} else {
-					str += "  "
+					str += ground(r, s, " ")
 				}
 			}
 
-			if s.Right {
+			if r.Excluded && (x == m.Width()-1 || roomExcluded(m, x+1, y)) {
+				str += "#"
+			} else if s.Right {
 				str += "|"
 			} else {
 				str += "_"
@@ -175,22 +313,98 @@ func PrintMaze(m MazeI) {
 	}
 }
 
+// ground returns the symbol for the portal, key, terrain or stairs in a room,
+// followed by the floor
+func ground(r *Room, s Survey, floor string) string {
+	if r.Portal {
+		return "◎" + floor
+	}
+	if r.Key != "" {
+		return "⚷" + floor
+	}
+	if symbol, ok := terrainSymbol[r.Terrain]; ok {
+		return symbol + floor
+	}
+
+	switch {
+	case !s.Up && !s.Down:
+		return "↕" + floor
+	case !s.Up:
+		return "↑" + floor
+	case !s.Down:
+		return "↓" + floor
+	}
+	return floor + floor
+}
+
+// roomExcluded returns if room (x, y) is solid rock
+func roomExcluded(m MazeI, x, y int) bool {
+	r, err := m.GetRoom(x, y)
+	return err == nil && r.Excluded
+}
+
 //////////////// Utilities added by Kelvin ////////////////
 
 // Delta gives the delta movement based on the direction to move
 var Delta = map[int]Coordinate{
-	N: Coordinate{0, -1},
-	S: Coordinate{0, 1},
-	E: Coordinate{1, 0},
-	W: Coordinate{-1, 0},
+	N: Coordinate{X: 0, Y: -1},
+	S: Coordinate{X: 0, Y: 1},
+	E: Coordinate{X: 1, Y: 0},
+	W: Coordinate{X: -1, Y: 0},
+	U: Coordinate{Z: 1},
+	D: Coordinate{Z: -1},
 }
 
 // Opposite gives the opposite direction
 var Opposite = map[int]int{
-	N: S,
-	S: N,
-	E: W,
-	W: E,
+	N:  S,
+	S:  N,
+	E:  W,
+	W:  E,
+	U:  D,
+	D:  U,
+	NE: SW,
+	SW: NE,
+	SE: NW,
+	NW: SE,
+}
+
+// Topology describes how rooms are laid out: the directions Icarus can
+// take and how far each of them moves him.
+type Topology struct {
+	Name       string
+	Directions []int
+	Delta      map[int]Coordinate
+}
+
+// Step returns the coordinates reached from c in the given direction
+func (t Topology) Step(c Coordinate, dir int) Coordinate {
+	d := t.Delta[dir]
+	return Coordinate{X: c.X + d.X, Y: c.Y + d.Y, Z: c.Z + d.Z}
+}
+
+// Square is the usual grid of rooms, possibly stacked in levels
+var Square = Topology{
+	Name:       "square",
+	Directions: []int{N, S, E, W, U, D},
+	Delta:      Delta,
+}
+
+// Hexagonal is a grid of hexagons with flat tops, in axial coordinates.
+// X counts columns and Y runs along the column, so that every direction
+// moves by the same amount wherever Icarus is.
+// HexMaze stores its rooms in offset coordinates, see HexMaze.Axial.
+var Hexagonal = Topology{
+	Name:       "hexagonal",
+	Directions: []int{N, NE, SE, S, SW, NW},
+	Delta: map[int]Coordinate{
+		N:  Coordinate{X: 0, Y: -1},
+		NE: Coordinate{X: 1, Y: -1},
+		SE: Coordinate{X: 1, Y: 0},
+		S:  Coordinate{X: 0, Y: 1},
+		SW: Coordinate{X: -1, Y: 1},
+		NW: Coordinate{X: -1, Y: 0},
+	},
 }
 
 // Shuffle randomly shuffles a slice of int
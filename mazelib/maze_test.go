@@ -0,0 +1,81 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+// corridor is a 3 x 1 maze with Icarus at the west end and the
+// treasure at the east end
+func corridor() *GridMaze {
+	m := NewFullGrid(3, 1)
+	m.RmWall(0, 0, E)
+	m.RmWall(1, 0, E)
+	m.SetStartPoint(0, 0)
+	m.SetTreasure(2, 0)
+	return m
+}
+
+// serpentine carves a perfect maze into m, a single corridor that winds
+// from the top left, along each row in turn
+func serpentine(m Carver) {
+	w, h := m.Width(), m.Height()
+	for y := 0; y < h; y++ {
+		for x := 0; x < w-1; x++ {
+			m.RmWall(x, y, E)
+		}
+		if y < h-1 {
+			if y%2 == 0 {
+				m.RmWall(w-1, y, S)
+			} else {
+				m.RmWall(0, y, S)
+			}
+		}
+	}
+}
+
+// comb carves a perfect maze into m, a corridor along the top row with
+// a tooth going down from each of its rooms, which all end in dead ends
+func comb(m Carver) {
+	w, h := m.Width(), m.Height()
+	for x := 0; x < w; x++ {
+		if x < w-1 {
+			m.RmWall(x, 0, E)
+		}
+		for y := 0; y < h-1; y++ {
+			m.RmWall(x, y, S)
+		}
+	}
+}
+
+// combGrid is an 8 x 8 maze carved by comb
+func combGrid() *GridMaze {
+	m := NewFullGrid(8, 8)
+	comb(m)
+	return m
+}
+
+// combTorus is an 8 x 8 torus carved by comb, so none of the walls
+// across its edges are open
+func combTorus() *GridMaze {
+	m := NewFullTorus(8, 8)
+	comb(m)
+	return m
+}
+
+// placement is a row of the tests that ask to place n of something in a
+// maze, where at most most of them fit
+type placement struct {
+	name string
+	maze func() *GridMaze
+	n    int
+	most int
+}
+
+// placements returns the rows every placement test starts with: none, a
+// few and many in a comb, where most of them fit, then a few on a torus
+func placements(few, many, most int) []placement {
+	return []placement{
+		{"none", combGrid, 0, 0},
+		{"a few", combGrid, few, few},
+		{"more than fit", combGrid, many, most},
+		{"torus", combTorus, few, few},
+	}
+}
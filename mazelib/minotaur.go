@@ -0,0 +1,165 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// ErrCaught indicates Icarus was caught by the Minotaur, and lost
+var ErrCaught = errors.New("Caught by the Minotaur")
+
+// Minotaur roams a maze with a single level, one room each time it
+// steps. Daedalus lets it step once for every 2 steps Icarus takes.
+// A Minotaur that hunts follows the scent Icarus leaves behind, freshest
+// first, and wanders where there is none. It needs no keys, locked doors
+// don't stop it.
+type Minotaur struct {
+	at    Coordinate
+	last  Coordinate
+	Hunts bool
+	scent map[Coordinate]int
+	time  int
+}
+
+// ErrNoLair indicates there is no room for the Minotaur far enough
+// from Icarus, as in a corridor with only Icarus and the treasure
+var ErrNoLair = errors.New("No room for the Minotaur away from Icarus")
+
+// NewMinotaur places a Minotaur in the maze, in a random room at least
+// half as far from Icarus as the furthest room he can reach. It returns
+// ErrNoLair if there is no such room that isn't the treasure.
+func NewMinotaur(m MazeI, hunts bool) (*Minotaur, error) {
+	ix, iy := m.Icarus()
+	dist := distances(m, Coordinate{X: ix, Y: iy}, -1)
+
+	far := 0
+	for _, d := range dist {
+		if d > far {
+			far = d
+		}
+	}
+	rooms := make([]Coordinate, 0)
+	for c, d := range dist {
+		if r, _ := m.GetRoom(c.X, c.Y); 2*d >= far && d > 0 && !r.Treasure {
+			rooms = append(rooms, c)
+		}
+	}
+
+	if len(rooms) == 0 {
+		return nil, ErrNoLair
+	}
+
+	t := &Minotaur{Hunts: hunts, scent: make(map[Coordinate]int)}
+	t.at = rooms[rand.Intn(len(rooms))]
+	t.last = t.at
+	return t, nil
+}
+
+// At returns the room the Minotaur is in
+func (t *Minotaur) At() (x, y int) {
+	return t.at.X, t.at.Y
+}
+
+// Catches returns if the Minotaur is in room (x, y)
+func (t *Minotaur) Catches(x, y int) bool {
+	return t.at == Coordinate{X: x, Y: y}
+}
+
+// Trail leaves the scent of Icarus in room (x, y)
+func (t *Minotaur) Trail(x, y int) {
+	t.time++
+	t.scent[Coordinate{X: x, Y: y}] = t.time
+}
+
+// Step moves the Minotaur to a neighbouring room. It doesn't turn back
+// unless it is in a dead end.
+func (t *Minotaur) Step(m MazeI) {
+	exits := openRooms(m, t.at)
+	if len(exits) == 0 {
+		return
+	}
+
+	next := exits[rand.Intn(len(exits))]
+	if t.Hunts {
+		// follow the freshest scent, if there is any
+		freshest := 0
+		for _, c := range exits {
+			if t.scent[c] > freshest {
+				freshest = t.scent[c]
+				next = c
+			}
+		}
+		if freshest > 0 {
+			t.last, t.at = t.at, next
+			return
+		}
+	}
+
+	if next == t.last && len(exits) > 1 {
+		for next == t.last {
+			next = exits[rand.Intn(len(exits))]
+		}
+	}
+	t.last, t.at = t.at, next
+}
+
+// Sense returns the direction to step in from room (x, y) to go towards
+// the Minotaur, if it is at most depth steps away
+func (t *Minotaur) Sense(m MazeI, x, y, depth int) (int, bool) {
+	src := Coordinate{X: x, Y: y}
+	if src == t.at {
+		return 0, false
+	}
+
+	// walk back from the Minotaur to the room next to Icarus
+	dist := distances(m, t.at, depth)
+	if _, ok := dist[src]; !ok {
+		return 0, false
+	}
+	s, _ := m.Discover(x, y)
+	for _, dir := range []int{N, S, E, W} {
+		next, ok := neighbour(src, dir, m.Width(), m.Height(), isTorus(m))
+		if d, found := dist[next]; ok && found && !wallFacing(s, dir) && d < dist[src] {
+			return dir, true
+		}
+	}
+	return 0, false
+}
+
+// openRooms returns the rooms that can be walked to from room c.
+// Locked doors are open, as if every key was held.
+func openRooms(m MazeI, c Coordinate) []Coordinate {
+	s, err := m.Discover(c.X, c.Y)
+	if err != nil {
+		return nil
+	}
+	rooms := make([]Coordinate, 0, 4)
+	for _, dir := range []int{N, S, E, W} {
+		if next, ok := neighbour(c, dir, m.Width(), m.Height(), isTorus(m)); ok && !wallFacing(s, dir) && !roomExcluded(m, next.X, next.Y) {
+			rooms = append(rooms, next)
+		}
+	}
+	return rooms
+}
+
+// distances returns the number of steps from src to every room that can
+// be reached in at most depth steps, or any number of steps if depth < 0
+func distances(m MazeI, src Coordinate, depth int) map[Coordinate]int {
+	dist := map[Coordinate]int{src: 0}
+	queue := []Coordinate{src}
+	for i := 0; i < len(queue); i++ {
+		cur := queue[i]
+		if depth >= 0 && dist[cur] >= depth {
+			continue
+		}
+		for _, next := range openRooms(m, cur) {
+			if _, seen := dist[next]; !seen {
+				dist[next] = dist[cur] + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+	return dist
+}
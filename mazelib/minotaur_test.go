@@ -0,0 +1,105 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+func TestNewMinotaur(t *testing.T) {
+	// Icarus at the top left of a comb is 14 steps from the furthest room
+	m := combGrid()
+	m.SetStartPoint(0, 0)
+	m.SetTreasure(7, 7)
+	dist := distances(m, Coordinate{X: 0, Y: 0}, -1)
+	for i := 0; i < 50; i++ {
+		mt, err := NewMinotaur(m, false)
+		if err != nil {
+			t.Fatalf("comb: %v", err)
+		}
+		x, y := mt.At()
+		if d := dist[Coordinate{X: x, Y: y}]; 2*d < 14 || x == 7 && y == 7 {
+			t.Fatalf("comb: Minotaur at (%d, %d), %d steps from Icarus", x, y, d)
+		}
+		if !mt.Catches(x, y) || mt.Catches(0, 0) {
+			t.Fatalf("comb: Minotaur at (%d, %d) catches in the wrong rooms", x, y)
+		}
+	}
+
+	// the only room away from Icarus holds the treasure
+	m = NewFullGrid(2, 1)
+	m.RmWall(0, 0, E)
+	m.SetStartPoint(0, 0)
+	m.SetTreasure(1, 0)
+	if mt, err := NewMinotaur(m, true); err != ErrNoLair {
+		t.Errorf("two rooms: got %v and %v, want %v", mt, err, ErrNoLair)
+	}
+}
+
+func TestMinotaurSense(t *testing.T) {
+	tests := []struct {
+		name  string
+		maze  func() *GridMaze
+		at    Coordinate
+		x, y  int
+		depth int
+		dir   int
+		hears bool
+	}{
+		{"down the tooth", combGrid, Coordinate{X: 3, Y: 2}, 3, 0, 3, S, true},
+		{"along the top", combGrid, Coordinate{X: 3, Y: 2}, 2, 0, 3, E, true},
+		{"too far", combGrid, Coordinate{X: 3, Y: 2}, 1, 0, 3, 0, false},
+		{"out of the next tooth", combGrid, Coordinate{X: 3, Y: 2}, 4, 1, 4, N, true},
+		{"same room", combGrid, Coordinate{X: 3, Y: 2}, 3, 2, 3, 0, false},
+		{"across the edge", func() *GridMaze { return NewEmptyTorus(4, 4) }, Coordinate{X: 0, Y: 0}, 3, 0, 3, E, true},
+	}
+
+	for _, tt := range tests {
+		mt := &Minotaur{at: tt.at, last: tt.at, scent: make(map[Coordinate]int)}
+		dir, hears := mt.Sense(tt.maze(), tt.x, tt.y, tt.depth)
+		if dir != tt.dir || hears != tt.hears {
+			t.Errorf("%s: Sense is %d, %t, want %d, %t", tt.name, dir, hears, tt.dir, tt.hears)
+		}
+	}
+}
+
+func TestMinotaurStep(t *testing.T) {
+	tests := []struct {
+		name  string
+		hunts bool
+		at    Coordinate
+		last  Coordinate
+		trail []Coordinate
+		want  []Coordinate
+	}{
+		// it doesn't turn back, so it goes on or down the tooth
+		{"wanders on", false, Coordinate{X: 3, Y: 0}, Coordinate{X: 2, Y: 0}, nil,
+			[]Coordinate{{X: 4, Y: 0}, {X: 3, Y: 1}}},
+		{"turns back in a dead end", false, Coordinate{X: 3, Y: 7}, Coordinate{X: 3, Y: 6}, nil,
+			[]Coordinate{{X: 3, Y: 6}}},
+		{"follows the freshest scent", true, Coordinate{X: 3, Y: 0}, Coordinate{X: 2, Y: 0},
+			[]Coordinate{{X: 3, Y: 1}, {X: 2, Y: 0}}, []Coordinate{{X: 2, Y: 0}}},
+		{"wanders without scent", true, Coordinate{X: 3, Y: 0}, Coordinate{X: 2, Y: 0}, nil,
+			[]Coordinate{{X: 4, Y: 0}, {X: 3, Y: 1}}},
+	}
+
+	m := combGrid()
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			mt := &Minotaur{at: tt.at, last: tt.last, Hunts: tt.hunts, scent: make(map[Coordinate]int)}
+			for _, c := range tt.trail {
+				mt.Trail(c.X, c.Y)
+			}
+			mt.Step(m)
+
+			x, y := mt.At()
+			ok := false
+			for _, c := range tt.want {
+				ok = ok || c == Coordinate{X: x, Y: y}
+			}
+			if !ok {
+				t.Fatalf("%s: Minotaur stepped to (%d, %d), want one of %v", tt.name, x, y, tt.want)
+			}
+		}
+	}
+}
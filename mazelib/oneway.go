@@ -0,0 +1,44 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"math/rand"
+)
+
+// OneWayCarver is implemented by mazes that can have one way doors.
+// A CompactMaze can't, since both rooms share the same wall.
+type OneWayCarver interface {
+	Carver
+	OneWay(x, y, dir int)
+}
+
+// PlaceOneWays adds up to n one way doors to the maze. Each door goes
+// through a wall that was closed on both sides, so every room that could
+// be reached before still can, and the maze just has a few shortcuts.
+// It returns the number of doors placed.
+func PlaceOneWays(m OneWayCarver, n int) int {
+	w, h := m.Width(), m.Height()
+	torus := m.Wraps()
+	dirs := []int{N, S, E, W}
+
+	placed := 0
+	for tries := 0; placed < n && tries < 10*n; tries++ {
+		c := Coordinate{X: rand.Intn(w), Y: rand.Intn(h)}
+		dir := dirs[rand.Intn(len(dirs))]
+		next, ok := neighbour(c, dir, w, h, torus)
+		if !ok || m.Excluded(c.X, c.Y) || m.Excluded(next.X, next.Y) {
+			continue
+		}
+
+		s, _ := m.Discover(c.X, c.Y)
+		t, _ := m.Discover(next.X, next.Y)
+		if !wallFacing(s, dir) || !wallFacing(t, Opposite[dir]) {
+			continue
+		}
+
+		m.OneWay(c.X, c.Y, dir)
+		placed++
+	}
+	return placed
+}
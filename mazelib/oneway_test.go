@@ -0,0 +1,58 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+func TestPlaceOneWays(t *testing.T) {
+	// a comb has 63 of its 112 walls open, so 49 are closed on both sides
+	tests := append(placements(5, 1000, 49),
+		placement{"no walls", func() *GridMaze { return NewEmptyGrid(4, 4) }, 10, 0},
+		placement{"corridor", corridor, 10, 0},
+	)
+
+	for _, tt := range tests {
+		m := tt.maze()
+		m.SetStartPoint(0, 0)
+		m.SetTreasure(m.Width()-1, m.Height()-1)
+		w, h := m.Width(), m.Height()
+		before := make(map[Coordinate]Survey)
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				before[Coordinate{X: x, Y: y}], _ = m.Discover(x, y)
+			}
+		}
+
+		placed := PlaceOneWays(m, tt.n)
+		if placed > tt.most {
+			t.Errorf("%s: %d one way doors, want at most %d", tt.name, placed, tt.most)
+		}
+
+		// each door opens one side of a wall that was closed on both
+		doors := 0
+		for c, s := range before {
+			now, _ := m.Discover(c.X, c.Y)
+			for _, dir := range []int{N, S, E, W} {
+				if !wallFacing(s, dir) || wallFacing(now, dir) {
+					continue
+				}
+				doors++
+				next, _ := neighbour(c, dir, w, h, m.Wraps())
+				back, _ := m.Discover(next.X, next.Y)
+				if !wallFacing(before[next], Opposite[dir]) || !wallFacing(back, Opposite[dir]) {
+					t.Errorf("%s: door from %v to %v isn't one way", tt.name, c, next)
+				}
+			}
+		}
+		if doors != placed {
+			t.Errorf("%s: %d walls opened, want %d", tt.name, doors, placed)
+		}
+
+		v := Validate(m)
+		if !v.ValidWithOneWays() || v.Regions != 1 || len(v.AsymmetricWalls) != placed {
+			t.Errorf("%s: %d one way doors broke the maze\n%s", tt.name, placed, v)
+		}
+	}
+}
@@ -0,0 +1,394 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Directions of a polar maze. A room may have several rooms outward of
+// it, Outward+i is the direction to the i-th of them, clockwise.
+const (
+	CW      = 11
+	CCW     = 12
+	In      = 13
+	Outward = 14
+)
+
+// maxOutward is the most rooms outward of a single room, which is
+// the centre of a polar maze
+const maxOutward = 6
+
+func init() {
+	DirectionName[CW] = "clockwise"
+	DirectionName[CCW] = "counterclockwise"
+	DirectionName[In] = "inward"
+	for i := 0; i < maxOutward; i++ {
+		DirectionName[Outward+i] = fmt.Sprintf("outward%d", i)
+	}
+}
+
+// DirectionByName returns the direction with the given name in
+// DirectionName, or false if there is none
+func DirectionByName(name string) (int, bool) {
+	for dir, n := range DirectionName {
+		if n == name {
+			return dir, true
+		}
+	}
+	return 0, false
+}
+
+// PolarSurvey Given a location, survey the sides of a room in a polar maze
+// True indicates a wall is present. Outward has a wall for each room
+// outward of this one, it is empty on the outermost ring.
+type PolarSurvey struct {
+	Inward           bool   `json:"inward"`
+	Clockwise        bool   `json:"clockwise"`
+	CounterClockwise bool   `json:"counterclockwise"`
+	Outward          []bool `json:"outward"`
+}
+
+// Wall returns if there is a wall in the given direction
+func (s PolarSurvey) Wall(dir int) bool {
+	switch {
+	case dir == In:
+		return s.Inward
+	case dir == CW:
+		return s.Clockwise
+	case dir == CCW:
+		return s.CounterClockwise
+	case dir >= Outward && dir < Outward+len(s.Outward):
+		return s.Outward[dir-Outward]
+	}
+	return true
+}
+
+// Open returns the directions without walls
+func (s PolarSurvey) Open() []int {
+	dirs := make([]int, 0, 3+len(s.Outward))
+	for _, dir := range []int{In, CW, CCW} {
+		if !s.Wall(dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	for i, wall := range s.Outward {
+		if !wall {
+			dirs = append(dirs, Outward+i)
+		}
+	}
+	return dirs
+}
+
+func (s *PolarSurvey) set(dir int, wall bool) {
+	switch {
+	case dir == In:
+		s.Inward = wall
+	case dir == CW:
+		s.Clockwise = wall
+	case dir == CCW:
+		s.CounterClockwise = wall
+	case dir >= Outward && dir < Outward+len(s.Outward):
+		s.Outward[dir-Outward] = wall
+	}
+}
+
+// copy returns a survey that doesn't share Outward with s
+func (s PolarSurvey) copy() PolarSurvey {
+	s.Outward = append([]bool{}, s.Outward...)
+	return s
+}
+
+// PolarRoom is a room of a polar maze
+type PolarRoom struct {
+	Treasure bool
+	Start    bool
+	Walls    PolarSurvey
+}
+
+// PolarReply is a PolarSurvey sent to a solver, see MazeReply.
+// Back is the direction to the room Icarus came from.
+type PolarReply struct {
+	Survey PolarSurvey
+	Back   int
+	Err    error
+}
+
+// PolarMaze is a "theta" maze of concentric rings around a single room
+// in the centre. Outer rings have more rooms so that rooms stay about
+// as wide as they are deep. Rooms are found by ring and by cell, counted
+// clockwise from the east.
+type PolarMaze struct {
+	rings      [][]PolarRoom
+	start      Coordinate // X is the cell and Y is the ring
+	end        Coordinate
+	icarus     Coordinate
+	back       int
+	StepsTaken int
+}
+
+// NewFullPolarMaze creates a polar maze with all walls
+// Good starting point for subtractive algorithms
+// http://weblog.jamisbuck.org/2011/2/3/maze-generation-theta-mazes
+func NewFullPolarMaze(rings int) *PolarMaze {
+	z := PolarMaze{rings: make([][]PolarRoom, rings)}
+
+	cells := 1
+	for r := 0; r < rings; r++ {
+		if r > 0 {
+			// how many rooms of the same depth fit in the ring
+			width := 2 * math.Pi * float64(r) / float64(cells)
+			cells *= int(math.Max(1, math.Floor(width+0.5)))
+		}
+		z.rings[r] = make([]PolarRoom, cells)
+	}
+
+	for r := range z.rings {
+		out := 0
+		if r < rings-1 {
+			out = len(z.rings[r+1]) / len(z.rings[r])
+		}
+		for c := range z.rings[r] {
+			z.rings[r][c].Walls = PolarSurvey{Inward: true, Clockwise: true, CounterClockwise: true, Outward: make([]bool, out)}
+			for i := range z.rings[r][c].Walls.Outward {
+				z.rings[r][c].Walls.Outward[i] = true
+			}
+		}
+	}
+
+	return &z
+}
+
+// Rings returns the number of rings, including the centre
+func (m *PolarMaze) Rings() int { return len(m.rings) }
+
+// Cells returns the number of rooms in a ring
+func (m *PolarMaze) Cells(ring int) int { return len(m.rings[ring]) }
+
+// Steps returns the number of steps Icarus has taken
+func (m *PolarMaze) Steps() int { return m.StepsTaken }
+
+// Cost returns the total cost of Icarus's steps, which all cost 1
+// in a polar maze
+func (m *PolarMaze) Cost() int { return m.StepsTaken }
+
+// Neighbour returns the room next to the room at (ring, cell) in
+// the given direction, or false if there is none
+func (m *PolarMaze) Neighbour(ring, cell, dir int) (nring, ncell int, ok bool) {
+	if ring < 0 || ring >= m.Rings() {
+		return ring, cell, false
+	}
+	n := m.Cells(ring)
+
+	switch {
+	case dir == CW && n > 1:
+		return ring, (cell + 1) % n, true
+	case dir == CCW && n > 1:
+		return ring, (cell + n - 1) % n, true
+	case dir == In && ring > 0:
+		return ring - 1, cell / (n / m.Cells(ring-1)), true
+	case dir >= Outward && ring < m.Rings()-1:
+		out := m.Cells(ring+1) / n
+		if i := dir - Outward; i < out {
+			return ring + 1, cell*out + i, true
+		}
+	}
+	return ring, cell, false
+}
+
+// reverse returns the direction from the neighbour in the given
+// direction back to the room at (ring, cell)
+func (m *PolarMaze) reverse(ring, cell, dir int) int {
+	switch {
+	case dir == CW:
+		return CCW
+	case dir == CCW:
+		return CW
+	case dir == In:
+		return Outward + cell%(m.Cells(ring)/m.Cells(ring-1))
+	}
+	return In
+}
+
+// GetRoom returns the room at (ring, cell)
+func (m *PolarMaze) GetRoom(ring, cell int) (*PolarRoom, error) {
+	if ring < 0 || ring >= m.Rings() || cell < 0 || cell >= m.Cells(ring) {
+		return &PolarRoom{}, ErrOutOfBounds
+	}
+
+	return &m.rings[ring][cell], nil
+}
+
+// Icarus returns the finder's current position
+func (m *PolarMaze) Icarus() (ring, cell int) {
+	return m.icarus.Y, m.icarus.X
+}
+
+// Back returns the direction to the room Icarus came from,
+// or 0 if he hasn't moved yet
+func (m *PolarMaze) Back() int { return m.back }
+
+// SetStartPoint sets the location where Icarus will awake
+func (m *PolarMaze) SetStartPoint(ring, cell int) error {
+	r, err := m.GetRoom(ring, cell)
+	if err != nil {
+		return err
+	}
+
+	if r.Treasure {
+		return errors.New("can't start in the treasure")
+	}
+
+	r.Start = true
+	m.start = Coordinate{X: cell, Y: ring}
+	m.icarus = m.start
+	m.back = 0
+	return nil
+}
+
+// SetTreasure sets the location of the treasure for a given maze
+func (m *PolarMaze) SetTreasure(ring, cell int) error {
+	r, err := m.GetRoom(ring, cell)
+	if err != nil {
+		return err
+	}
+
+	if r.Start {
+		return errors.New("can't have the treasure at the start")
+	}
+
+	r.Treasure = true
+	m.end = Coordinate{X: cell, Y: ring}
+	return nil
+}
+
+// LookAround Given Icarus's current location, Discover that room
+// Will return ErrVictory if Icarus is at the treasure.
+func (m *PolarMaze) LookAround() (PolarSurvey, error) {
+	if m.end == m.icarus {
+		return PolarSurvey{}, ErrVictory
+	}
+
+	return m.Discover(m.Icarus())
+}
+
+// Discover Given a ring and a cell, survey the room.
+// Will return error if the room is outside of the maze
+func (m *PolarMaze) Discover(ring, cell int) (PolarSurvey, error) {
+	r, err := m.GetRoom(ring, cell)
+	if err != nil {
+		return PolarSurvey{}, err
+	}
+	return r.Walls.copy(), nil
+}
+
+// Move Moves Icarus's position one step in the given direction
+// Will not permit moving through walls or out of the maze
+func (m *PolarMaze) Move(dir int) error {
+	s, e := m.LookAround()
+	if e != nil {
+		return e
+	}
+	if s.Wall(dir) {
+		return ErrWall
+	}
+
+	ring, cell := m.Icarus()
+	nring, ncell, ok := m.Neighbour(ring, cell, dir)
+	if !ok {
+		return ErrOutOfBounds
+	}
+
+	m.back = m.reverse(ring, cell, dir)
+	m.icarus = Coordinate{X: ncell, Y: nring}
+	m.StepsTaken++
+	return nil
+}
+
+// AddWall adds a wall to the room at (ring, cell) and the matching wall to its neighbour
+func (m *PolarMaze) AddWall(ring, cell, dir int) {
+	m.setWall(ring, cell, dir, true)
+}
+
+// RmWall removes a wall from the room at (ring, cell) and the matching wall from its neighbour
+func (m *PolarMaze) RmWall(ring, cell, dir int) {
+	m.setWall(ring, cell, dir, false)
+}
+
+func (m *PolarMaze) setWall(ring, cell, dir int, wall bool) {
+	if r, err := m.GetRoom(ring, cell); err == nil {
+		r.Walls.set(dir, wall)
+	}
+	if nring, ncell, ok := m.Neighbour(ring, cell, dir); ok {
+		m.rings[nring][ncell].Walls.set(m.reverse(ring, cell, dir), wall)
+	}
+}
+
+// polarDepth is the depth of a ring in the SVG, in pixels
+const polarDepth = 16.0
+
+// WritePolarSVG draws the maze as an SVG image.
+// Icarus is a blue dot and the treasure a gold dot.
+func WritePolarSVG(w io.Writer, m *PolarMaze) error {
+	size := 2*polarDepth*float64(m.Rings()) + 4
+	centre := size / 2
+	point := func(radius, angle float64) (float64, float64) {
+		return centre + radius*math.Cos(angle), centre + radius*math.Sin(angle)
+	}
+
+	if _, err := fmt.Fprintf(w, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%.0f\" height=\"%.0f\">\n"+
+		"<rect width=\"100%%\" height=\"100%%\" fill=\"white\"/>\n"+
+		"<g stroke=\"black\" stroke-width=\"2\" stroke-linecap=\"round\" fill=\"none\">\n", size, size); err != nil {
+		return err
+	}
+
+	iring, icell := m.Icarus()
+	dots := ""
+	for r := range m.rings {
+		n := len(m.rings[r])
+		inner, outer := polarDepth*float64(r), polarDepth*float64(r+1)
+		for c := range m.rings[r] {
+			room := &m.rings[r][c]
+			a1 := 2 * math.Pi * float64(c) / float64(n)
+			a2 := 2 * math.Pi * float64(c+1) / float64(n)
+
+			// the centre has no walls of its own, they belong to the first ring
+			var err error
+			if r > 0 && room.Walls.Inward {
+				x1, y1 := point(inner, a1)
+				x2, y2 := point(inner, a2)
+				_, err = fmt.Fprintf(w, "<path d=\"M %.1f %.1f A %.1f %.1f 0 0 1 %.1f %.1f\"/>\n", x1, y1, inner, inner, x2, y2)
+			}
+			if err == nil && r > 0 && room.Walls.Clockwise {
+				x1, y1 := point(inner, a2)
+				x2, y2 := point(outer, a2)
+				_, err = fmt.Fprintf(w, "<line x1=\"%.1f\" y1=\"%.1f\" x2=\"%.1f\" y2=\"%.1f\"/>\n", x1, y1, x2, y2)
+			}
+			if err == nil && r == m.Rings()-1 {
+				x1, y1 := point(outer, a1)
+				x2, y2 := point(outer, a2)
+				_, err = fmt.Fprintf(w, "<path d=\"M %.1f %.1f A %.1f %.1f 0 0 1 %.1f %.1f\"/>\n", x1, y1, outer, outer, x2, y2)
+			}
+			if err != nil {
+				return err
+			}
+
+			x, y := point((inner+outer)/2, (a1+a2)/2)
+			if r == 0 {
+				x, y = centre, centre
+			}
+			switch {
+			case room.Treasure:
+				dots += fmt.Sprintf("<circle cx=\"%.1f\" cy=\"%.1f\" r=\"4\" fill=\"gold\"/>\n", x, y)
+			case r == iring && c == icell:
+				dots += fmt.Sprintf("<circle cx=\"%.1f\" cy=\"%.1f\" r=\"4\" fill=\"blue\"/>\n", x, y)
+			}
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "</g>\n%s</svg>\n", dots)
+	return err
+}
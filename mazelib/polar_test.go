@@ -0,0 +1,70 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+func TestPolarMove(t *testing.T) {
+	// three rings of 1, 6 and 12 rooms
+	tests := []struct {
+		name         string
+		ring, cell   int
+		open         []int
+		moves        []int
+		err          error
+		nring, ncell int
+		back         int
+		steps        int
+	}{
+		{"out of the centre", 0, 0, []int{Outward + 2}, []int{Outward + 2}, nil, 1, 2, In, 1},
+		{"clockwise round the end of a ring", 1, 5, []int{CW}, []int{CW}, nil, 1, 0, CCW, 1},
+		{"counterclockwise round the start of a ring", 1, 0, []int{CCW}, []int{CCW}, nil, 1, 5, CW, 1},
+		{"inward", 2, 7, []int{In}, []int{In}, nil, 1, 3, Outward + 1, 1},
+		{"outward", 1, 3, []int{Outward + 1}, []int{Outward + 1}, nil, 2, 7, In, 1},
+		{"there and back", 1, 3, []int{In}, []int{In, Outward + 3}, nil, 1, 3, In, 2},
+		{"wall", 1, 3, nil, []int{CW}, ErrWall, 1, 3, 0, 0},
+		{"inward from the centre", 0, 0, nil, []int{In}, ErrWall, 0, 0, 0, 0},
+		{"outward from the outer ring", 2, 0, nil, []int{Outward}, ErrWall, 2, 0, 0, 0},
+		{"hexagonal direction", 1, 3, nil, []int{NE}, ErrWall, 1, 3, 0, 0},
+	}
+
+	for _, tt := range tests {
+		m := NewFullPolarMaze(3)
+		m.SetStartPoint(tt.ring, tt.cell)
+		m.SetTreasure(2, 11)
+		for _, dir := range tt.open {
+			m.RmWall(tt.ring, tt.cell, dir)
+		}
+		var err error
+		for _, dir := range tt.moves {
+			err = m.Move(dir)
+		}
+		if ring, cell := m.Icarus(); err != tt.err || ring != tt.nring || cell != tt.ncell {
+			t.Errorf("%s: moved to (%d, %d) with %v, want (%d, %d) with %v", tt.name, ring, cell, err, tt.nring, tt.ncell, tt.err)
+		}
+		if m.Back() != tt.back {
+			t.Errorf("%s: the way back is %s, want %s", tt.name, DirectionName[m.Back()], DirectionName[tt.back])
+		}
+		if m.Steps() != tt.steps || m.Cost() != tt.steps {
+			t.Errorf("%s: %d steps costing %d, want %d", tt.name, m.Steps(), m.Cost(), tt.steps)
+		}
+	}
+}
+
+func TestPolarVictory(t *testing.T) {
+	m := NewFullPolarMaze(3)
+	m.SetStartPoint(0, 0)
+	m.SetTreasure(1, 4)
+	m.RmWall(0, 0, Outward+4)
+	if err := m.Move(Outward + 4); err != nil {
+		t.Fatalf("move onto the treasure gave %v", err)
+	}
+	if _, err := m.LookAround(); err != ErrVictory {
+		t.Errorf("LookAround on the treasure gave %v, want %v", err, ErrVictory)
+	}
+	if err := m.Move(In); err != ErrVictory || m.Steps() != 1 {
+		t.Errorf("move on from the treasure gave %v after %d steps, want %v after 1", err, m.Steps(), ErrVictory)
+	}
+}
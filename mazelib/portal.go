@@ -0,0 +1,59 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"math/rand"
+)
+
+// Linker is implemented by mazes that can have portals
+type Linker interface {
+	Carver
+	Link(a, b Coordinate) error
+}
+
+// PlacePortals links up to n pairs of dead ends with portals.
+// A dead end is never on the way between two other rooms, so every room
+// that could be reached before still can, and the maze stays solvable.
+// It returns the number of pairs placed.
+func PlacePortals(m Linker, n int) int {
+	ends := deadEnds(m)
+	for i := range ends {
+		j := rand.Intn(i + 1)
+		ends[i], ends[j] = ends[j], ends[i]
+	}
+
+	placed := 0
+	for i := 0; placed < n && i+1 < len(ends); i += 2 {
+		if m.Link(ends[i], ends[i+1]) == nil {
+			placed++
+		}
+	}
+	return placed
+}
+
+// deadEnds returns the rooms with a single way in or out
+func deadEnds(m Carver) []Coordinate {
+	w, h := m.Width(), m.Height()
+	torus := m.Wraps()
+
+	ends := []Coordinate{}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if m.Excluded(x, y) {
+				continue
+			}
+			s, _ := m.Discover(x, y)
+			open := 0
+			for _, dir := range []int{N, S, E, W} {
+				if _, ok := neighbour(Coordinate{X: x, Y: y}, dir, w, h, torus); ok && !wallFacing(s, dir) {
+					open++
+				}
+			}
+			if open == 1 {
+				ends = append(ends, Coordinate{X: x, Y: y})
+			}
+		}
+	}
+	return ends
+}
@@ -0,0 +1,83 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+// reachable returns the rooms Icarus can walk to from where he is,
+// going through the portals as he would. He never stays in a portal.
+func reachable(m *GridMaze) map[Coordinate]bool {
+	x, y := m.Icarus()
+	start := Coordinate{X: x, Y: y}
+	seen := map[Coordinate]bool{start: true}
+	queue := []Coordinate{start}
+	for i := 0; i < len(queue); i++ {
+		cur := queue[i]
+		s, _ := m.Discover(cur.X, cur.Y)
+		for _, dir := range []int{N, S, E, W} {
+			next, ok := neighbour(cur, dir, m.Width(), m.Height(), m.Wraps())
+			if !ok || wallFacing(s, dir) {
+				continue
+			}
+			if to, ok := m.Portal(next.X, next.Y); ok {
+				next = to
+			}
+			if !seen[next] {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return seen
+}
+
+func TestPlacePortals(t *testing.T) {
+	// a comb's dead ends are the ends of its 8 teeth, and one of them holds
+	// the treasure, so 7 are left for 3 pairs
+	tests := append(placements(1, 100, 3),
+		placement{"no dead ends", func() *GridMaze { return NewEmptyGrid(4, 4) }, 5, 0},
+		// the only dead ends hold Icarus and the treasure
+		placement{"corridor", corridor, 5, 0},
+	)
+
+	for _, tt := range tests {
+		m := tt.maze()
+		m.SetStartPoint(0, 0)
+		m.SetTreasure(m.Width()-1, m.Height()-1)
+
+		placed := PlacePortals(m, tt.n)
+		if placed > tt.most {
+			t.Errorf("%s: %d pairs of portals, want at most %d", tt.name, placed, tt.most)
+		}
+
+		portals := 0
+		for y := 0; y < m.Height(); y++ {
+			for x := 0; x < m.Width(); x++ {
+				to, ok := m.Portal(x, y)
+				if !ok {
+					continue
+				}
+				portals++
+				if back, _ := m.Portal(to.X, to.Y); back != (Coordinate{X: x, Y: y}) {
+					t.Errorf("%s: portal (%d, %d) leads to %v, which leads to %v", tt.name, x, y, to, back)
+				}
+			}
+		}
+		if portals != 2*placed {
+			t.Errorf("%s: %d portals, want %d", tt.name, portals, 2*placed)
+		}
+
+		// every room but the portals can still be walked to
+		seen := reachable(m)
+		for y := 0; y < m.Height(); y++ {
+			for x := 0; x < m.Width(); x++ {
+				r, _ := m.GetRoom(x, y)
+				if !r.Portal && !seen[Coordinate{X: x, Y: y}] {
+					t.Errorf("%s: room (%d, %d) can't be reached through %d pairs of portals", tt.name, x, y, placed)
+				}
+			}
+		}
+	}
+}
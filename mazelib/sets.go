@@ -0,0 +1,36 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+// Sets are disjoint sets of rooms numbered from 0, which Kruskal's
+// algorithm joins as it knocks down walls
+type Sets []int
+
+// NewSets puts each of n rooms in a set of its own
+func NewSets(n int) Sets {
+	s := make(Sets, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+// Find returns the set room i is in
+func (s Sets) Find(i int) int {
+	for s[i] != i {
+		s[i] = s[s[i]]
+		i = s[i]
+	}
+	return i
+}
+
+// Union joins the sets of rooms a and b. It returns false if they
+// were in the same set already.
+func (s Sets) Union(a, b int) bool {
+	a, b = s.Find(a), s.Find(b)
+	if a == b {
+		return false
+	}
+	s[b] = a
+	return true
+}
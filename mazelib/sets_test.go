@@ -0,0 +1,35 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+func TestSets(t *testing.T) {
+	s := NewSets(5)
+	tests := []struct {
+		a, b   int
+		joined bool
+	}{
+		{0, 1, true},
+		{1, 0, false},
+		{2, 3, true},
+		{1, 3, true},
+		{0, 2, false},
+		{4, 4, false},
+	}
+	for _, tt := range tests {
+		if got := s.Union(tt.a, tt.b); got != tt.joined {
+			t.Errorf("Union(%d, %d) = %t, want %t", tt.a, tt.b, got, tt.joined)
+		}
+	}
+	for i := 1; i < 4; i++ {
+		if s.Find(i) != s.Find(0) {
+			t.Errorf("room %d is not in the set of room 0", i)
+		}
+	}
+	if s.Find(4) == s.Find(0) {
+		t.Errorf("room 4 joined the set of room 0")
+	}
+}
@@ -0,0 +1,53 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"math/rand"
+)
+
+// ShiftRegion rearranges the walls between the rooms of the square of
+// size x size rooms from (x, y). The passages inside the square are all
+// closed and then opened again at random, as Kruskal would, only where
+// they join rooms that can't reach each other. The maze stays as
+// connected as it was, so Icarus is never sealed in, and a perfect maze
+// stays perfect.
+func ShiftRegion(m Carver, x, y, size int) {
+	w, h := m.Width(), m.Height()
+	inside := func(c Coordinate) bool {
+		return c.X >= x && c.X < x+size && c.Y >= y && c.Y < y+size && !m.Excluded(c.X, c.Y)
+	}
+
+	// close the passages inside the square
+	type edge struct {
+		c   Coordinate
+		dir int
+	}
+	edges := make([]edge, 0, 2*size*size)
+	for j := y; j < y+size && j < h; j++ {
+		for i := x; i < x+size && i < w; i++ {
+			c := Coordinate{X: i, Y: j}
+			if !inside(c) {
+				continue
+			}
+			for _, dir := range []int{E, S} {
+				if next, ok := neighbour(c, dir, w, h, false); ok && inside(next) {
+					edges = append(edges, edge{c, dir})
+					m.AddWall(i, j, dir)
+				}
+			}
+		}
+	}
+
+	label, regions := labelRegions(m)
+	sets := NewSets(regions + 1)
+
+	// open them again in random order, joining what they cut apart
+	for _, i := range rand.Perm(len(edges)) {
+		e := edges[i]
+		next := Square.Step(e.c, e.dir)
+		if sets.Union(label[e.c.Y*w+e.c.X], label[next.Y*w+next.X]) {
+			m.RmWall(e.c.X, e.c.Y, e.dir)
+		}
+	}
+}
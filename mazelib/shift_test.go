@@ -0,0 +1,39 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+func TestShiftRegion(t *testing.T) {
+	compact := NewFullCompactMaze(8, 8)
+	comb(compact)
+	// the rock at the end of the first tooth leaves the comb perfect
+	rock := combGrid()
+	rock.Exclude(0, 7)
+
+	tests := []struct {
+		name       string
+		m          MazeI
+		x, y, size int
+	}{
+		{"corner", combGrid(), 0, 0, 3},
+		{"middle", combGrid(), 2, 3, 4},
+		{"over the edge", combGrid(), 5, 6, 4},
+		{"whole maze", combGrid(), 0, 0, 8},
+		{"compact", compact, 1, 1, 5},
+		{"torus", combTorus(), 4, 4, 4},
+		{"with rock", rock, 0, 4, 4},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 50; i++ {
+			ShiftRegion(tt.m.(Carver), tt.x, tt.y, tt.size)
+			v := Validate(tt.m)
+			if !v.Perfect || v.Regions != 1 {
+				t.Fatalf("%s: shift %d left %d regions, perfect is %t\n%s", tt.name, i, v.Regions, v.Perfect, v)
+			}
+		}
+	}
+}
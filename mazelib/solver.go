@@ -5,50 +5,87 @@ package mazelib
 import (
 	"errors"
 	"fmt"
+	"math/rand"
+	"sort"
 )
 
 // MazeReply is a struct to represent the reply form the maze server
+// Teleported is true if the last step went into a portal, Shifted if the
+// walls moved after it, and Keys are the colours of the keys Icarus holds.
+// Danger is the direction of the Minotaur when it is near, or 0.
+// Sight is only set in reply to a Look. Heat is how hot the hint of
+// the server is, see Heat, or 0 without hints. Width and Height are the
+// size of the maze and Position where Icarus is in it, if the server tells.
 type MazeReply struct {
-	Survey Survey
-	Err    error
+	Survey     Survey
+	Teleported bool
+	Shifted    bool
+	Keys       []string
+	Danger     int
+	Sight      *Sight
+	Heat       int
+	Width      int
+	Height     int
+	Position   *Coordinate
+	Torus      bool
+	Remaining  int
+	PickedUp   bool
+	Err        error
 }
 
-// directionToMove is a utility method that returns the direction (N,S,E,W)
+// directionToMove is a utility method that returns the direction (N,S,E,W,U,D)
 // to take to move from src to dest coordinates. the coordinates must be adjacent
 func directionToMove(src, dest Coordinate) (direction int) {
-	dx, dy := dest.X-src.X, dest.Y-src.Y
+	dx, dy, dz := dest.X-src.X, dest.Y-src.Y, dest.Z-src.Z
 	switch {
-	case dx == 1 && dy == 0:
+	case dx == 1 && dy == 0 && dz == 0:
 		direction = E
-	case dx == -1 && dy == 0:
+	case dx == -1 && dy == 0 && dz == 0:
 		direction = W
-	case dx == 0 && dy == 1:
+	case dx == 0 && dy == 1 && dz == 0:
 		direction = S
-	case dx == 0 && dy == -1:
+	case dx == 0 && dy == -1 && dz == 0:
 		direction = N
+	case dx == 0 && dy == 0 && dz == 1:
+		direction = U
+	case dx == 0 && dy == 0 && dz == -1:
+		direction = D
 	}
 	return
 }
 
-// updatePosition is a utility method that returns the new x, y coordinates
-// given a current(x,y) and the direction to move
-func updatePosition(cx, cy, direction int) (newx, newy int) {
-	newx, newy = cx, cy
-	switch direction {
-	case N:
-		newy--
-	case S:
-		newy++
-	case E:
-		newx++
-	case W:
-		newx--
+// updatePosition is a utility method that returns the new coordinates
+// given a current coordinates and the direction to move
+func updatePosition(cur Coordinate, direction int) Coordinate {
+	return Coordinate{
+		X: cur.X + Delta[direction].X,
+		Y: cur.Y + Delta[direction].Y,
+		Z: cur.Z + Delta[direction].Z,
 	}
+}
 
-	// var s string
-	// fmt.Scanln(&s)
-
-	return newx, newy
+// openDirections returns the directions without walls in a survey
+func openDirections(survey Survey) []int {
+	dirs := make([]int, 0, 6)
+	if !survey.Left {
+		dirs = append(dirs, W)
+	}
+	if !survey.Right {
+		dirs = append(dirs, E)
+	}
+	if !survey.Top {
+		dirs = append(dirs, N)
+	}
+	if !survey.Bottom {
+		dirs = append(dirs, S)
+	}
+	if !survey.Up {
+		dirs = append(dirs, U)
+	}
+	if !survey.Down {
+		dirs = append(dirs, D)
+	}
+	return dirs
 }
 
 //////////// Dijkstra's algorithm ////////////
@@ -60,6 +97,37 @@ func updatePosition(cx, cy, direction int) (newx, newy int) {
 // accessible. Essentially it represents graph of nodes and their neighbours
 type adjacencyMap map[Coordinate][]Coordinate
 
+// add adds dest to the paths of src, unless it is there already
+func (g adjacencyMap) add(src, dest Coordinate) {
+	for _, path := range g[src] {
+		if path == dest {
+			return
+		}
+	}
+	g[src] = append(g[src], dest)
+}
+
+// remove removes dest from the paths of src
+func (g adjacencyMap) remove(src, dest Coordinate) {
+	paths := g[src]
+	for i, path := range paths {
+		if path == dest {
+			g[src] = append(paths[:i:i], paths[i+1:]...)
+			return
+		}
+	}
+}
+
+// jumpMap holds the edges of an adjacencyMap between rooms that are not
+// next to each other, such as through a portal, and the direction to
+// step in to take them
+type jumpMap map[[2]Coordinate]int
+
+// frameGap is the difference of level between the frames of coordinates
+// of FindTreasure. The far side of each portal is in a new frame, since
+// it can't tell where it came out.
+const frameGap = 1000
+
 const infinity = 1000000
 
 type node struct {
@@ -90,22 +158,28 @@ func nextNodeID(nodes map[Coordinate]*node) (Coordinate, error) {
 	return item, errors.New("No more unprocessed nodes with some distance")
 }
 
-// given a current point (x, y), figure out the length of the shortest path
-// for each of the junctions we want to reach.
-// Return a list of steps for the nearest junction
-func shortestPath(cx, cy int, graph adjacencyMap, junctions adjacencyMap) []int {
+// given a current point, figure out the cost of the cheapest path
+// for each of the junctions we want to reach. costs holds the cost of
+// entering the rooms where it is known to be more than 1.
+// Return the rooms on the way to the nearest junction
+func shortestPath(source Coordinate, graph adjacencyMap, junctions adjacencyMap, costs map[Coordinate]int) []Coordinate {
 	destinations := make(map[Coordinate]bool, len(junctions))
 	for k := range junctions {
 		destinations[k] = true
 	}
 
 	// initialise
-	source := Coordinate{cx, cy}
 
+	// in a directed graph, rooms may only be known as a neighbour
 	n := len(graph)
 	nodes := make(map[Coordinate]*node, n)
-	for k := range graph {
+	for k, neighbours := range graph {
 		nodes[k] = &node{processed: false, dist: infinity}
+		for _, neighbour := range neighbours {
+			if _, found := nodes[neighbour]; !found {
+				nodes[neighbour] = &node{processed: false, dist: infinity}
+			}
+		}
 	}
 	nodes[source].dist = 0
 
@@ -130,41 +204,48 @@ func shortestPath(cx, cy int, graph adjacencyMap, junctions adjacencyMap) []int
 			if nodes[neighbour].processed {
 				continue
 			}
-			if nodes[cur].dist+1 < nodes[neighbour].dist {
-				nodes[neighbour].dist = nodes[cur].dist + 1
+			cost := roomCost(costs, neighbour)
+			if dz := neighbour.Z - cur.Z; dz == 1 || dz == -1 {
+				cost = StairsCost
+			}
+			if nodes[cur].dist+cost < nodes[neighbour].dist {
+				nodes[neighbour].dist = nodes[cur].dist + cost
 				nodes[neighbour].parent = cur
 			}
 		}
 	}
 
 	// find which junction is nearest
-	numSteps := infinity
+	nearest := infinity
 	var junction Coordinate
 
 	for dest := range junctions {
-		if nodes[dest].dist < numSteps {
-			numSteps = nodes[dest].dist
+		if n, found := nodes[dest]; found && n.dist < nearest {
+			nearest = nodes[dest].dist
 			junction = dest
 		}
 	}
+	if nearest == infinity {
+		return nil
+	}
 
 	// get the route of the target junction to go to
-	route := make([]Coordinate, numSteps)
-	target := junction
-	for target != source {
-		numSteps--
-		route[numSteps] = target
-		target = nodes[target].parent
+	route := []Coordinate{}
+	for target := junction; target != source; target = nodes[target].parent {
+		route = append(route, target)
 	}
+	for i, j := 0, len(route)-1; i < j; i, j = i+1, j-1 {
+		route[i], route[j] = route[j], route[i]
+	}
+	return route
+}
 
-	directions := make([]int, 0, numSteps)
-	prev := source
-	for _, cur := range route {
-		direction := directionToMove(prev, cur)
-		directions = append(directions, direction)
-		prev = cur
+// roomCost returns the cost of entering a room, 1 unless costs says otherwise
+func roomCost(costs map[Coordinate]int, c Coordinate) int {
+	if cost, ok := costs[c]; ok {
+		return cost
 	}
-	return directions
+	return 1
 }
 
 //////////// Main Solver ////////////
@@ -172,19 +253,40 @@ type bounds struct {
 	xmin, ymin, xmax, ymax int
 }
 
-var boundary bounds
-var lastDirection int
+// extend grows b to take in room c
+func (b *bounds) extend(c Coordinate) {
+	if c.X > b.xmax {
+		b.xmax = c.X
+	} else if c.X < b.xmin {
+		b.xmin = c.X
+	}
+	if c.Y > b.ymax {
+		b.ymax = c.Y
+	} else if c.Y < b.ymin {
+		b.ymin = c.Y
+	}
+}
+
+// within returns where the rooms of a w x h maze can be, given that the
+// rooms seen so far are within b. At is where the start is in the maze,
+// if known, which places the maze exactly.
+func (b bounds) within(w, h int, at *Coordinate) bounds {
+	if at != nil {
+		return bounds{xmin: -at.X, ymin: -at.Y, xmax: w - 1 - at.X, ymax: h - 1 - at.Y}
+	}
+	return bounds{xmin: b.xmax - w + 1, ymin: b.ymax - h + 1, xmax: b.xmin + w - 1, ymax: b.ymin + h - 1}
+}
 
 // A junction is a node that has at least one unvisited neighbour.
-// Junction A and B may both point to another node (cx, cy) as
-// an unvisited place.  If cx, cy is later newly visited,
+// Junction A and B may both point to another node cur as
+// an unvisited place.  If cur is later newly visited,
 // it must be removed from both A and B.
-func cleanUpJunctions(cx, cy int, junctions adjacencyMap) {
+func cleanUpJunctions(cur Coordinate, junctions adjacencyMap) {
 	for k, paths := range junctions {
 		newPaths := make([]Coordinate, 0, 4)
 
 		for _, path := range paths {
-			if path.X != cx || path.Y != cy {
+			if path != cur {
 				newPaths = append(newPaths, path)
 			}
 		}
@@ -197,11 +299,35 @@ func cleanUpJunctions(cx, cy int, junctions adjacencyMap) {
 	}
 }
 
+// hottest returns the junctions with the hottest hint, or all of them
+// if there are no hints
+func hottest(junctions adjacencyMap, heat map[Coordinate]int) adjacencyMap {
+	most := 0
+	for room := range junctions {
+		if heat[room] > most {
+			most = heat[room]
+		}
+	}
+	if most == 0 {
+		return junctions
+	}
+
+	hot := make(adjacencyMap)
+	for room, paths := range junctions {
+		if heat[room] == most {
+			hot[room] = paths
+		}
+	}
+	return hot
+}
+
 // Given the current location (x, y) and the possible
 // coordinates to move, pick the best way to go.
-// Requires knowledge of estimated bounds and where has been visited
+// Requires knowledge of the area the maze may cover and where has been visited
 // Only useful if the maze has few walls
-func priortisePaths(cx, cy int, paths []Coordinate, visited map[Coordinate]bool) {
+// Stairs count the unexplored rooms of the whole level they lead to.
+func priortisePaths(cur Coordinate, paths []Coordinate, visited map[Coordinate]bool, area bounds) {
+	cx, cy := cur.X, cur.Y
 	if len(paths) < 2 {
 		// there's nothing to prioritise if you have only 1 or 0 paths.
 		return
@@ -211,32 +337,37 @@ func priortisePaths(cx, cy int, paths []Coordinate, visited map[Coordinate]bool)
 		dx, dy := path.X-cx, path.Y-cy
 		var startx, endx, starty, endy int
 		switch {
+		case path.Z != cur.Z: // take the stairs
+			startx = area.xmin
+			endx = area.xmax
+			starty = area.ymin
+			endy = area.ymax
 		case dy == -1: //move north
-			startx = boundary.xmin
-			endx = boundary.xmax
-			starty = boundary.ymin
+			startx = area.xmin
+			endx = area.xmax
+			starty = area.ymin
 			endy = path.Y
 		case dy == 1: //move south
-			startx = boundary.xmin
-			endx = boundary.xmax
+			startx = area.xmin
+			endx = area.xmax
 			starty = path.Y
-			endy = boundary.ymax
+			endy = area.ymax
 		case dx == -1: //move west
-			startx = boundary.ymin
+			startx = area.xmin
 			endx = path.X
-			starty = boundary.ymin
-			endy = boundary.ymax
+			starty = area.ymin
+			endy = area.ymax
 		case dx == 1: // move east
 			startx = path.X
-			endx = boundary.ymin
-			starty = boundary.ymin
-			endy = boundary.ymax
+			endx = area.xmax
+			starty = area.ymin
+			endy = area.ymax
 		}
 
 		u := 0
 		for x := startx; x <= endx; x++ {
 			for y := starty; y <= endy; y++ {
-				if _, found := visited[Coordinate{x, y}]; !found {
+				if _, found := visited[Coordinate{X: x, Y: y, Z: path.Z}]; !found {
 					u++
 				}
 			}
@@ -270,147 +401,544 @@ func priortisePaths(cx, cy int, paths []Coordinate, visited map[Coordinate]bool)
 // FindTreasure combined with priortisePaths reduces the average to 77
 // Note: FindTreasure (with or without path prioritising) DOES NOT perform
 // better than Tremaux for mazes with no loops
+// FindTreasure assumes every passage can be walked both ways. When it
+// finds one can't, it drops the passage and plans again from where it is.
+// FindTreasureDirected bumps into fewer walls in mazes with one way doors.
 func FindTreasure(replies <-chan MazeReply) <-chan int {
-	steps := make(chan int)
+	return findTreasure(replies, false, false)
+}
 
-	// clear boundary at the start
-	boundary = bounds{}
-	estWidth, estHeight := 1, 1
+// FindTreasureDirected is FindTreasure for mazes with one way doors.
+// Its graph only has the passages it has seen open from the room it was
+// in, so it only plans routes it knows it can walk.
+func FindTreasureDirected(replies <-chan MazeReply) <-chan int {
+	return findTreasure(replies, true, false)
+}
 
-	// relative x and y to starting position
-	cx, cy := 0, 0
+// FindTreasureLooking is FindTreasure, but it looks down the corridors
+// from the start and from every new junction. When it sees the treasure
+// it goes straight for it. Each Look is answered with the Sight.
+func FindTreasureLooking(replies <-chan MazeReply) <-chan int {
+	return findTreasure(replies, false, true)
+}
 
-	// graph maps a (x, y) to connected (x, y)s
-	graph := make(adjacencyMap)
+// findTreasure is FindTreasure, with a directed graph if directed is true,
+// looking down the corridors at junctions if looking is true
+func findTreasure(replies <-chan MazeReply, directed, looking bool) <-chan int {
+	e := newExplorer(replies, directed, looking)
+	go e.explore()
+	return e.steps
+}
 
-	// keep track of all junctions that have at least one unvisited (x, y)
-	junctions := make(adjacencyMap)
+// explorer is the state of findTreasure. It maps the maze as Icarus walks
+// it, in coordinates relative to where he woke up, and keeps what each
+// mode of the server tells it apart, with helpers of its own.
+type explorer struct {
+	replies <-chan MazeReply
+	steps   chan int
+
+	directed, looking bool
+
+	// cur is where Icarus is
+	cur Coordinate
+
+	// graph maps a room to the rooms it leads to
+	graph adjacencyMap
+
+	// keep track of all junctions that have at least one unvisited room
+	junctions adjacencyMap
 
 	// visited tracks the rooms that have been visited
-	visited := make(map[Coordinate]bool)
+	visited map[Coordinate]bool
+
+	// costs tracks the cost of entering rooms, where it is more than 1
+	costs map[Coordinate]int
+
+	// pending is the reply of the room we backtracked to
+	pending *MazeReply
+
+	// jumps tracks the steps into portals, from the room before the
+	// portal to where they come out. landing maps each portal room found
+	// to the far side it leads to, and frames counts the far sides.
+	jumps   jumpMap
+	landing map[Coordinate]Coordinate
+	frames  int
+
+	// held tracks the keys Icarus holds, and doors the locked doors seen
+	// for each key that isn't held yet. Keys are never used up, so the
+	// search over rooms and keys held is the same search as without keys,
+	// where each new key opens the doors of its colour seen so far.
+	held  map[string]bool
+	doors map[string][][2]Coordinate
+
+	// epoch counts the times the walls have shifted, and surveyed tracks
+	// the epoch each room was last surveyed in
+	epoch    int
+	surveyed map[Coordinate]int
+
+	// looked tracks the rooms we looked from, and treasures the rooms
+	// with a treasure we saw that we haven't been to yet
+	looked    map[Coordinate]bool
+	treasures adjacencyMap
+
+	// seen is the area the rooms seen so far are in. width and height are
+	// the size of the maze, and origin where the start is in it, if the
+	// server tells. On a torus the coordinates wrap around at width and
+	// height, so each room has just the one.
+	seen          bounds
+	width, height int
+	origin        *Coordinate
+	torus         bool
+
+	// heat tracks the hint of each room visited, if the server gives them
+	heat map[Coordinate]int
+}
 
-	go func() {
-		for {
-			visited[Coordinate{cx, cy}] = true
-			reply := <-replies
+func newExplorer(replies <-chan MazeReply, directed, looking bool) *explorer {
+	return &explorer{
+		replies:   replies,
+		steps:     make(chan int),
+		directed:  directed,
+		looking:   looking,
+		graph:     make(adjacencyMap),
+		junctions: make(adjacencyMap),
+		visited:   make(map[Coordinate]bool),
+		costs:     make(map[Coordinate]int),
+		jumps:     make(jumpMap),
+		landing:   make(map[Coordinate]Coordinate),
+		held:      make(map[string]bool),
+		doors:     make(map[string][][2]Coordinate),
+		surveyed:  make(map[Coordinate]int),
+		looked:    make(map[Coordinate]bool),
+		treasures: make(adjacencyMap),
+		heat:      make(map[Coordinate]int),
+	}
+}
 
-			survey, err := reply.Survey, reply.Err
-			if err == ErrVictory {
-				// solved, we are done
-				break
-			}
+// explore walks the maze until the treasure is found or the maze is over
+func (e *explorer) explore() {
+	defer close(e.steps)
+	for {
+		e.visited[e.cur] = true
+		var reply MazeReply
+		if e.pending != nil {
+			reply, e.pending = *e.pending, nil
+		} else {
+			reply = <-e.replies
+		}
+		if Over(reply.Err) {
+			// solved, lost to the Minotaur or out of steps, we are done
+			return
+		}
 
-			//possible directions it can go from current x, y
-			dirs := make([]int, 0, 4)
-			if !survey.Left {
-				dirs = append(dirs, W)
+		if reply.Teleported {
+			var ok bool
+			if reply, ok = e.throughPortal(reply); !ok {
+				return
 			}
-			if !survey.Right {
-				dirs = append(dirs, E)
-			}
-			if !survey.Top {
-				dirs = append(dirs, N)
+		}
+		// we have the treasure here, if there was one
+		delete(e.treasures, e.cur)
+		e.pickUp(reply.Keys)
+		e.resurvey(reply)
+		e.heed(reply)
+
+		paths := e.openPaths(reply.Survey)
+		e.graph[e.cur] = paths
+
+		// of all the possible paths, how many unvisited previously?
+		uvPaths := make([]Coordinate, 0, 4)
+		for _, path := range paths {
+			if !e.visited[path] {
+				uvPaths = append(uvPaths, path)
 			}
-			if !survey.Bottom {
-				dirs = append(dirs, S)
+		}
+
+		e.look(reply, len(uvPaths))
+		if e.seek(reply, uvPaths) {
+			continue
+		}
+
+		if len(uvPaths) == 0 {
+			// deadend, need to backtrack
+			if !e.backtrack(reply) {
+				return
 			}
-			Shuffle(dirs)
+			continue
+		}
+		e.step(e.choose(uvPaths, reply.Danger))
+	}
+}
 
-			// get all possible paths/coordinates you can go
-			paths := make([]Coordinate, 0, 4)
-			for _, dir := range dirs {
-				newX, newY := cx+Delta[dir].X, cy+Delta[dir].Y
-				paths = append(paths, Coordinate{newX, newY})
-
-				// explore the new boundary
-				if newX > boundary.xmax {
-					boundary.xmax = newX
-				} else if newX < boundary.xmin {
-					boundary.xmin = newX
-				}
-				if newY > boundary.ymax {
-					boundary.ymax = newY
-				} else if newY < boundary.ymin {
-					boundary.ymin = newY
-				}
+// next returns the room a step in dir leads to from c, which is on the
+// opposite edge when it goes off the edge of a torus
+func (e *explorer) next(c Coordinate, dir int) Coordinate {
+	c = updatePosition(c, dir)
+	if e.torus {
+		c.X = (c.X%e.width + e.width) % e.width
+		c.Y = (c.Y%e.height + e.height) % e.height
+	}
+	return c
+}
+
+// toward returns the direction to step in to go from src to dest,
+// which must be next to each other, if need be across the edge of a torus
+func (e *explorer) toward(src, dest Coordinate) int {
+	for _, dir := range []int{N, S, E, W, U, D} {
+		if e.next(src, dir) == dest {
+			return dir
+		}
+	}
+	return 0
+}
+
+// direction returns the direction to step in to go from src to dest,
+// which must be next to each other or joined by a jump
+func (e *explorer) direction(src, dest Coordinate) int {
+	if dir, ok := e.jumps[[2]Coordinate{src, dest}]; ok {
+		return dir
+	}
+	return e.toward(src, dest)
+}
+
+// contradicts returns if the survey of the room Icarus is in doesn't
+// match the paths the graph has for it
+func (e *explorer) contradicts(survey Survey) bool {
+	for _, dir := range []int{N, S, E, W} {
+		next := e.next(e.cur, dir)
+		known := false
+		for _, path := range e.graph[e.cur] {
+			known = known || path == next
+		}
+		if known == wallFacing(survey, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// step moves Icarus to the next room in the given direction
+func (e *explorer) step(dir int) {
+	e.cur = e.next(e.cur, dir)
+	cleanUpJunctions(e.cur, e.junctions)
+	e.steps <- dir
+}
+
+// throughPortal is called when the last step went into a portal and came
+// out somewhere we can't place. The rooms on the other side get a frame
+// of coordinates of their own, and we step out and back in to come out
+// of the portal we went in. It returns the reply of the room we are in
+// then, or false if the maze is over or we are stuck.
+func (e *explorer) throughPortal(reply MazeReply) (MazeReply, bool) {
+	portal := e.cur
+	e.frames++
+	far := Coordinate{Z: e.frames * frameGap}
+	dirs := openDirections(reply.Survey)
+	if len(dirs) == 0 {
+		fmt.Println("Stuck on the other side of a portal!")
+		return reply, false
+	}
+	out := dirs[0]
+	near := e.next(far, out)
+	e.visited[far], e.visited[near] = true, true
+
+	// every room next to the portal leads to the far side
+	e.landing[portal] = far
+	for room, paths := range e.graph {
+		for i, path := range paths {
+			if path == portal {
+				e.jumps[[2]Coordinate{room, far}] = e.toward(room, portal)
+				paths[i] = far
 			}
+		}
+	}
+	e.graph[far] = []Coordinate{near}
+	e.graph[near] = []Coordinate{portal}
+	e.jumps[[2]Coordinate{near, portal}] = Opposite[out]
 
-			// add possible paths to graph
-			graph[Coordinate{cx, cy}] = paths
+	e.steps <- out
+	if reply = <-e.replies; Over(reply.Err) {
+		return reply, false
+	}
+	e.steps <- Opposite[out]
+	reply = <-e.replies
+	return reply, !Over(reply.Err)
+}
 
-			//add reverse directions to graph
-			for _, path := range paths {
-				newPaths, found := graph[path]
-				if !found {
-					newPaths = make([]Coordinate, 0, 4)
-				}
-				newPaths = append(newPaths, Coordinate{cx, cy})
-				graph[path] = newPaths
+// pickUp opens the locked doors seen so far of the keys Icarus has
+// picked up since the last reply
+func (e *explorer) pickUp(keys []string) {
+	for _, k := range keys {
+		if e.held[k] {
+			continue
+		}
+		e.held[k] = true
+		for _, d := range e.doors[k] {
+			from, to := d[0], d[1]
+			e.graph.add(from, to)
+			if !e.directed {
+				e.graph.add(to, from)
 			}
+			if !e.visited[to] {
+				e.junctions[from] = append(e.junctions[from], to)
+			}
+		}
+		delete(e.doors, k)
+	}
+}
 
-			if (estWidth != boundary.xmax-boundary.xmin+1) ||
-				(estHeight != boundary.ymax-boundary.ymin+1) {
-				estWidth = boundary.xmax - boundary.xmin + 1
-				estHeight = boundary.ymax - boundary.ymin + 1
+// resurvey drops the passages from the room Icarus is in that the walls
+// have closed, once they have shifted
+func (e *explorer) resurvey(reply MazeReply) {
+	if reply.Shifted {
+		e.epoch++
+	}
+	if e.epoch > 0 {
+		// walls may have moved since we were last here
+		for _, dir := range []int{N, S, E, W} {
+			if next := e.next(e.cur, dir); wallFacing(reply.Survey, dir) {
+				e.graph.remove(e.cur, next)
+				e.graph.remove(next, e.cur)
+				e.junctions.remove(e.cur, next)
 			}
+		}
+		if len(e.junctions[e.cur]) == 0 {
+			delete(e.junctions, e.cur)
+		}
+	}
+	e.surveyed[e.cur] = e.epoch
+}
 
-			// of all the possible paths, how many unvisited previously?
-			uvPaths := make([]Coordinate, 0, 4)
-			for _, path := range paths {
-				if !visited[path] {
-					uvPaths = append(uvPaths, path)
-				}
+// heed keeps the hint of the room Icarus is in, and the size of the maze
+// if the server tells it when he wakes up
+func (e *explorer) heed(reply MazeReply) {
+	if reply.Heat > 0 {
+		e.heat[e.cur] = reply.Heat
+	}
+	if len(e.visited) == 1 && reply.Width > 0 {
+		e.width, e.height, e.origin = reply.Width, reply.Height, reply.Position
+		e.torus = reply.Torus
+	}
+}
+
+// openPaths returns the rooms Icarus can go to from where he is, in
+// random order, and adds the way back from them to the graph. A locked
+// door is kept for when we have its key, and a portal we know of leads
+// to its far side.
+func (e *explorer) openPaths(survey Survey) []Coordinate {
+	dirs := openDirections(survey)
+	Shuffle(dirs)
+
+	paths := make([]Coordinate, 0, 4)
+	for _, dir := range dirs {
+		next := e.next(e.cur, dir)
+		if lock := survey.Lock(dir); lock != "" && !e.held[lock] {
+			// it opens once we have the key
+			e.doors[lock] = append(e.doors[lock], [2]Coordinate{e.cur, next})
+			continue
+		}
+		if c := survey.Cost(dir); c > 1 && dir != U && dir != D {
+			e.costs[next] = c
+		}
+		e.seen.extend(next)
+		if to, ok := e.landing[next]; ok {
+			// stepping into a portal we know of
+			e.jumps[[2]Coordinate{e.cur, to}] = dir
+			next = to
+		} else if !e.directed {
+			// add the reverse direction to the graph
+			// a directed graph only knows it once we have been there
+			back := e.cur
+			if to, ok := e.landing[e.cur]; ok {
+				// we are in a portal, stepping back in leads out of the far side
+				e.jumps[[2]Coordinate{next, to}] = Opposite[dir]
+				back = to
 			}
+			e.graph.add(next, back)
+		}
+		paths = append(paths, next)
+	}
+	return paths
+}
 
-			unvisited := len(uvPaths)
-			var nextCoor Coordinate
-			var nextDir int
+// look looks down the corridors from the start, from every new junction
+// and from where a treasure was picked up while there are more, when
+// looking, unless there is a treasure we know of to go to already
+func (e *explorer) look(reply MazeReply, unvisited int) {
+	fresh := unvisited > 1 || len(e.visited) == 1 || (reply.PickedUp && reply.Remaining > 0)
+	if !e.looking || len(e.treasures) > 0 || e.looked[e.cur] || !fresh {
+		return
+	}
+	e.looked[e.cur] = true
+	e.steps <- Look
+	if r := <-e.replies; r.Sight != nil {
+		e.sight(*r.Sight)
+	}
+}
 
-			if unvisited == 0 {
-				// deadend, need to backtrack
-				stepsBack := shortestPath(cx, cy, graph, junctions)
-				if len(stepsBack) == 0 {
-					fmt.Println("Visited all places, but can't find treasured!")
-					break
-				}
+// sight keeps the treasure in sight, if there is one, and the passages
+// down the corridor to it
+func (e *explorer) sight(s Sight) {
+	dir, rooms := s.Towards()
+	from := e.cur
+	for i := 0; i < rooms; i++ {
+		next := e.next(from, dir)
+		if _, ok := e.landing[next]; ok {
+			// a portal is in the way, the treasure isn't where it looks
+			return
+		}
+		e.graph.add(from, next)
+		if !e.directed {
+			e.graph.add(next, from)
+		}
+		from = next
+	}
+	if rooms > 0 {
+		e.treasures[from] = nil
+	}
+}
 
-				// backtrack as prescribed to a junction with a unvisted neighbour
-				for _, dir := range stepsBack {
-					cx, cy = updatePosition(cx, cy, dir)
-					steps <- dir
-					// read the reply from server and discard, not important
-					// since they are all visited steps
-					<-replies
-				}
+// seek takes a step towards the nearest treasure we know of, unless the
+// Minotaur is that way. It returns if it took one.
+func (e *explorer) seek(reply MazeReply, uvPaths []Coordinate) bool {
+	route := shortestPath(e.cur, e.graph, e.treasures, e.costs)
+	if len(route) == 0 {
+		return false
+	}
+	next := route[0]
+	dir := e.direction(e.cur, next)
+	if dir == reply.Danger {
+		return false
+	}
 
-				if len(junctions[Coordinate{cx, cy}]) == 0 {
-					panic("Invalid map. There is a one way wall")
-				}
+	rest := make([]Coordinate, 0, len(uvPaths))
+	for _, path := range uvPaths {
+		if path != next {
+			rest = append(rest, path)
+		}
+	}
+	if len(rest) > 0 {
+		e.junctions[e.cur] = rest
+	} else {
+		delete(e.junctions, e.cur)
+	}
+	if _, ok := e.jumps[[2]Coordinate{e.cur, next}]; ok {
+		// back through a portal we know of, which mustn't
+		// be taken for a new one
+		e.walk([]Coordinate{next}, reply)
+		return true
+	}
+	e.cur = next
+	cleanUpJunctions(e.cur, e.junctions)
+	e.steps <- dir
+	return true
+}
 
-				// pick a new path to go
-				priortisePaths(cx, cy, uvPaths, visited)
-				nextCoor = junctions[Coordinate{cx, cy}][0]
-				nextDir = directionToMove(Coordinate{cx, cy}, nextCoor)
-			} else {
-				priortisePaths(cx, cy, uvPaths, visited)
-				if unvisited > 1 {
-					// more than 1 path, remember this junction so we can come back
-					junctions[Coordinate{cx, cy}] = uvPaths[1:]
-				}
+// backtrack walks from a dead end to the nearest junction, or the hottest
+// if there are hints. It returns false if there is nowhere left to go.
+func (e *explorer) backtrack(reply MazeReply) bool {
+	delete(e.junctions, e.cur)
+	// head for the hottest junctions first, if there are hints
+	route := shortestPath(e.cur, e.graph, hottest(e.junctions, e.heat), e.costs)
+	if len(route) == 0 {
+		route = shortestPath(e.cur, e.graph, e.junctions, e.costs)
+	}
+	if len(route) == 0 && e.epoch > 0 {
+		// the walls have shifted, the rooms we haven't seen
+		// since may open on places we haven't been
+		stale := make(adjacencyMap)
+		for room, epoch := range e.surveyed {
+			if epoch < e.epoch {
+				stale[room] = nil
+			}
+		}
+		route = shortestPath(e.cur, e.graph, stale, e.costs)
+	}
+	if len(route) == 0 {
+		fmt.Println("Visited all places, but can't find treasured!")
+		return false
+	}
 
-				nextCoor = uvPaths[0]
-				nextDir = directionToMove(Coordinate{cx, cy}, nextCoor)
+	if dir := e.direction(e.cur, route[0]); reply.Danger != 0 && dir == reply.Danger {
+		// the Minotaur is that way, step aside if we can
+		for _, path := range e.graph[e.cur] {
+			if dir = e.toward(e.cur, path); dir != reply.Danger && e.next(e.cur, dir) == path {
+				route = []Coordinate{path}
+				break
 			}
+		}
+	}
 
-			cx, cy = updatePosition(cx, cy, nextDir)
-			cleanUpJunctions(cx, cy, junctions)
-			steps <- nextDir
+	// backtrack as prescribed to a junction with a unvisted neighbour
+	e.walk(route, reply)
+	return true
+}
+
+// walk takes the steps of a route through rooms we know of, from the
+// room of reply. It stops early on a wall it didn't know of, where the
+// walls have moved or where the Minotaur is near, to think again. The
+// reply of the room it stops in is left pending, to look around there.
+func (e *explorer) walk(route []Coordinate, reply MazeReply) {
+	last := reply
+	for _, next := range route {
+		e.steps <- e.direction(e.cur, next)
+		r := <-e.replies
+		if Over(r.Err) {
+			e.pending = &r
+			return
+		}
+		if r.Err != nil {
+			// there is a wall we didn't know of
+			e.graph.remove(e.cur, next)
+			e.graph.remove(next, e.cur)
+			break
+		}
+		e.cur, last = next, r
+		delete(e.treasures, e.cur)
+		if r.Shifted {
+			e.epoch++
+		}
+		if e.epoch > 0 && e.contradicts(r.Survey) {
+			// the walls here have moved, think again
+			break
+		}
+		if r.Danger != 0 {
+			// the Minotaur is near, think again
+			break
 		}
+	}
 
-		close(steps)
-	}()
+	// look around where we stopped and pick a new path to go
+	last.Teleported, last.Shifted = false, false
+	e.pending = &last
+}
 
-	return steps
+// choose picks which of the unvisited rooms next to Icarus to go to, and
+// remembers the others if he is at a junction. It returns the direction.
+func (e *explorer) choose(uvPaths []Coordinate, danger int) int {
+	area := e.seen
+	if e.torus {
+		// the coordinates wrap around, every room is in the maze
+		area = bounds{xmax: e.width - 1, ymax: e.height - 1}
+	} else if e.width > 0 {
+		area = e.seen.within(e.width, e.height, e.origin)
+	}
+	priortisePaths(e.cur, uvPaths, e.visited, area)
+	// rooms that are cheap to enter first, it may be the way to the treasure
+	sort.SliceStable(uvPaths, func(i, j int) bool {
+		return roomCost(e.costs, uvPaths[i]) < roomCost(e.costs, uvPaths[j])
+	})
+	if danger != 0 {
+		// the Minotaur is near, go the other way if we can
+		sort.SliceStable(uvPaths, func(i, j int) bool {
+			return e.toward(e.cur, uvPaths[i]) != danger && e.toward(e.cur, uvPaths[j]) == danger
+		})
+	}
+	if len(uvPaths) > 1 {
+		// more than 1 path, remember this junction so we can come back
+		e.junctions[e.cur] = uvPaths[1:]
+	}
+	return e.toward(e.cur, uvPaths[0])
 }
 
 // Tremaux receives the surround surveys on replies channel
@@ -420,8 +948,8 @@ func FindTreasure(replies <-chan MazeReply) <-chan int {
 func Tremaux(replies <-chan MazeReply) <-chan int {
 	steps := make(chan int)
 
-	// relative x and y to starting position
-	cx, cy := 0, 0
+	// relative x, y and level to starting position
+	var cur Coordinate
 
 	// keep track of all junctions that have at least one unvisited (x, y)
 	junctions := make(adjacencyMap)
@@ -433,37 +961,24 @@ func Tremaux(replies <-chan MazeReply) <-chan int {
 	sequence := []Coordinate{}
 	go func() {
 		for {
-			visited[Coordinate{cx, cy}] = true
-			sequence = append(sequence, Coordinate{cx, cy})
+			visited[cur] = true
+			sequence = append(sequence, cur)
 			reply := <-replies
 
 			survey, err := reply.Survey, reply.Err
-			if err == ErrVictory {
-				// solved, we are done
+			if Over(err) {
+				// solved, or the maze is over, we are done
 				break
 			}
 
-			//possible directions it can go from current x, y
-			dirs := make([]int, 0, 4)
-			if !survey.Left {
-				dirs = append(dirs, W)
-			}
-			if !survey.Right {
-				dirs = append(dirs, E)
-			}
-			if !survey.Top {
-				dirs = append(dirs, N)
-			}
-			if !survey.Bottom {
-				dirs = append(dirs, S)
-			}
+			//possible directions it can go from current x, y and level
+			dirs := openDirections(survey)
 			Shuffle(dirs)
 
 			// get all possible paths/coordinates you can go
 			paths := make([]Coordinate, 0, 4)
 			for _, dir := range dirs {
-				newX, newY := cx+Delta[dir].X, cy+Delta[dir].Y
-				paths = append(paths, Coordinate{newX, newY})
+				paths = append(paths, updatePosition(cur, dir))
 			}
 
 			// of all the possible paths, how many unvisited previously?
@@ -491,15 +1006,72 @@ func Tremaux(replies <-chan MazeReply) <-chan int {
 			} else {
 				if unvisited > 1 {
 					// more than 1 path, remember this junction so we can come back
-					junctions[Coordinate{cx, cy}] = uvPaths[1:]
+					junctions[cur] = uvPaths[1:]
 				}
 
 				nextCoor = uvPaths[0]
 			}
 
-			nextDir = directionToMove(Coordinate{cx, cy}, nextCoor)
-			cx, cy = updatePosition(cx, cy, nextDir)
-			cleanUpJunctions(cx, cy, junctions)
+			nextDir = directionToMove(cur, nextCoor)
+			cur = updatePosition(cur, nextDir)
+			cleanUpJunctions(cur, junctions)
+			steps <- nextDir
+		}
+
+		close(steps)
+	}()
+
+	return steps
+}
+
+// HexTremaux receives the surveys of a hexagonal maze on replies channel
+// and recommends the steps on the output channel.
+// It is a depth first search like Tremaux, walking in axial coordinates
+// relative to the starting position, see Hexagonal.
+func HexTremaux(replies <-chan HexReply) <-chan int {
+	steps := make(chan int)
+
+	var cur Coordinate
+
+	// visited tracks the rooms that have been visited
+	visited := make(map[Coordinate]bool)
+
+	// sequence of directions taken. useful for backtracking
+	sequence := []int{}
+	go func() {
+		for {
+			visited[cur] = true
+			reply := <-replies
+
+			if Over(reply.Err) {
+				// solved, or the maze is over, we are done
+				break
+			}
+
+			dirs := reply.Survey.Open()
+			Shuffle(dirs)
+
+			nextDir := 0
+			for _, dir := range dirs {
+				if !visited[Hexagonal.Step(cur, dir)] {
+					nextDir = dir
+					break
+				}
+			}
+
+			if nextDir == 0 {
+				// deadend, need to backtrack
+				if len(sequence) == 0 {
+					fmt.Println("Visited all places, but can't find treasured!")
+					break
+				}
+				nextDir = Opposite[sequence[len(sequence)-1]]
+				sequence = sequence[:len(sequence)-1]
+			} else {
+				sequence = append(sequence, nextDir)
+			}
+
+			cur = Hexagonal.Step(cur, nextDir)
 			steps <- nextDir
 		}
 
@@ -508,3 +1080,174 @@ func Tremaux(replies <-chan MazeReply) <-chan int {
 
 	return steps
 }
+
+// PolarBacktracker receives the surveys of a polar maze on replies channel
+// and recommends the steps on the output channel.
+// It is ExitBacktracker, with the exits labelled by DirectionName.
+func PolarBacktracker(replies <-chan PolarReply) <-chan int {
+	steps := make(chan int)
+	exitReplies := make(chan GraphReply)
+	exitSteps := ExitBacktracker(exitReplies)
+
+	go func() {
+		for {
+			reply, ok := <-replies
+			if !ok {
+				break
+			}
+			exits := ExitSurvey{Exits: make([]string, 0, 6)}
+			for _, dir := range reply.Survey.Open() {
+				exits.Exits = append(exits.Exits, DirectionName[dir])
+			}
+			exitReplies <- GraphReply{Survey: exits, Back: DirectionName[reply.Back], Err: reply.Err}
+
+			label, ok := <-exitSteps
+			if !ok {
+				break
+			}
+			dir, _ := DirectionByName(label)
+			steps <- dir
+		}
+
+		close(steps)
+	}()
+
+	return steps
+}
+
+// ExitBacktracker receives the surveys of any maze on replies channel
+// and recommends the labels of the exits to take on the output channel.
+// Rooms have no fixed number of neighbours, so it does not keep a map
+// of the maze. Instead it remembers the way back to every room on its
+// path, which is only enough for perfect mazes.
+func ExitBacktracker(replies <-chan GraphReply) <-chan string {
+	steps := make(chan string)
+
+	// a room on the path, with the exits not tried yet
+	type room struct {
+		untried []string
+		back    string
+	}
+
+	go func() {
+		path := []*room{}
+		forward := true
+		for {
+			reply := <-replies
+			if Over(reply.Err) {
+				// solved, or the maze is over, we are done
+				break
+			}
+
+			if forward {
+				r := &room{back: reply.Back}
+				for _, label := range reply.Survey.Exits {
+					if label != reply.Back {
+						r.untried = append(r.untried, label)
+					}
+				}
+				for i := range r.untried {
+					j := rand.Intn(i + 1)
+					r.untried[i], r.untried[j] = r.untried[j], r.untried[i]
+				}
+				path = append(path, r)
+			}
+
+			cur := path[len(path)-1]
+			if len(cur.untried) > 0 {
+				forward = true
+				steps <- cur.untried[0]
+				cur.untried = cur.untried[1:]
+				continue
+			}
+
+			// deadend, need to backtrack
+			if cur.back == "" {
+				fmt.Println("Visited all places, but can't find treasured!")
+				break
+			}
+			forward = false
+			path = path[:len(path)-1]
+			steps <- cur.back
+		}
+
+		close(steps)
+	}()
+
+	return steps
+}
+
+// Solve runs a solver such as FindTreasure on a maze held locally,
+// answering each step the way Daedalus would, without keys or portals.
+// It tells the solver the size of a torus, and the treasures left to pick
+// up if there are several, as Daedalus does.
+// It returns the steps taken and if the treasure was found. The solver
+// is stopped with ErrStepLimit after limit steps, if limit is above 0.
+func Solve(m MazeI, solver func(<-chan MazeReply) <-chan int, limit int) (int, bool) {
+	replies := make(chan MazeReply)
+	steps := solver(replies)
+	survey, err := m.LookAround()
+	start := MazeReply{Survey: survey, Err: err}
+	start.Remaining, start.PickedUp = hoard(m)
+	if isTorus(m) {
+		// the solver needs the size to know a room when it comes round again
+		start.Width, start.Height, start.Torus = m.Width(), m.Height(), true
+	}
+	replies <- start
+
+	taken := 0
+	for step := range steps {
+		if step == Look {
+			x, y := m.Icarus()
+			sight := LineOfSight(m, x, y)
+			survey, err = m.LookAround()
+			replies <- MazeReply{Survey: survey, Sight: &sight, Err: err}
+			continue
+		}
+
+		taken++
+		if limit > 0 && taken > limit {
+			err = ErrStepLimit
+			replies <- MazeReply{Err: err}
+			continue
+		}
+		switch step {
+		case N:
+			err = m.MoveUp()
+		case S:
+			err = m.MoveDown()
+		case E:
+			err = m.MoveRight()
+		case W:
+			err = m.MoveLeft()
+		case U:
+			err = m.MoveAbove()
+		case D:
+			err = m.MoveBelow()
+		default:
+			err = ErrInvalidDirection
+		}
+		if err == nil {
+			survey, err = m.LookAround()
+		} else {
+			x, y := m.Icarus()
+			survey, _ = m.Discover(x, y)
+		}
+		r := MazeReply{Survey: survey, Err: err}
+		r.Remaining, r.PickedUp = hoard(m)
+		replies <- r
+	}
+	if err == ErrStepLimit {
+		taken--
+	}
+	return taken, err == ErrVictory
+}
+
+// hoard returns the treasures left to pick up in m, and if Icarus picked
+// one up on his last step, if m can have several
+func hoard(m MazeI) (int, bool) {
+	if h, ok := m.(Hoarder); ok {
+		return h.Remaining(), h.PickedUp()
+	}
+	return 0, false
+}
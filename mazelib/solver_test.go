@@ -0,0 +1,156 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// oneWayMaze is a perfect 6 x 6 maze with shortcuts through one way
+// doors, and Icarus and the treasure in random rooms
+func oneWayMaze() *GridMaze {
+	m := NewFullGrid(6, 6)
+	serpentine(m)
+	PlaceOneWays(m, 12)
+	m.SetStartPoint(rand.Intn(6), rand.Intn(6))
+	for m.SetTreasure(rand.Intn(6), rand.Intn(6)) != nil {
+	}
+	return m
+}
+
+func TestFindTreasureOneWay(t *testing.T) {
+	tests := []struct {
+		name   string
+		solver func(<-chan MazeReply) <-chan int
+	}{
+		{"FindTreasure", FindTreasure},
+		{"FindTreasureDirected", FindTreasureDirected},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 200; i++ {
+			m := oneWayMaze()
+			// every room can still be reached both ways without the
+			// doors, so the solver must get there however it walks
+			if steps, found := Solve(m, tt.solver, 1000); !found {
+				t.Fatalf("%s: no treasure after %d steps", tt.name, steps)
+			}
+		}
+	}
+}
+
+func TestFindTreasureTorus(t *testing.T) {
+	mazes := []struct {
+		name string
+		make func() *GridMaze
+	}{
+		{"empty", func() *GridMaze { return NewEmptyTorus(6, 4) }},
+		{"ring", func() *GridMaze {
+			m := NewFullTorus(8, 1)
+			for x := 0; x < 8; x++ {
+				m.RmWall(x, 0, E)
+			}
+			return m
+		}},
+		{"serpentine", func() *GridMaze {
+			// the rows join up round the east and west edges
+			m := NewFullTorus(5, 5)
+			serpentine(m)
+			for y := 0; y < 5; y++ {
+				m.RmWall(4, y, E)
+			}
+			return m
+		}},
+	}
+	solvers := []struct {
+		name   string
+		solver func(<-chan MazeReply) <-chan int
+	}{
+		{"FindTreasure", FindTreasure},
+		{"FindTreasureDirected", FindTreasureDirected},
+		{"FindTreasureLooking", FindTreasureLooking},
+	}
+
+	for _, mz := range mazes {
+		for _, s := range solvers {
+			for i := 0; i < 50; i++ {
+				m := mz.make()
+				w, h := m.Width(), m.Height()
+				m.SetStartPoint(rand.Intn(w), rand.Intn(h))
+				for m.SetTreasure(rand.Intn(w), rand.Intn(h)) != nil {
+				}
+				if steps, found := Solve(m, s.solver, 4*w*h); !found {
+					t.Fatalf("%s on %s torus: no treasure after %d steps", s.name, mz.name, steps)
+				}
+			}
+
+			// without knowing of the torus, the solver takes the same
+			// rooms round again for new ones and never runs out of them
+			m := mz.make()
+			w, h := m.Width(), m.Height()
+			m.SetStartPoint(0, 0)
+			m.SetTreasure(w-1, h-1)
+			for _, dir := range []int{N, S, E, W} {
+				m.AddWall(w-1, h-1, dir)
+			}
+			if steps, found := Solve(m, s.solver, 4*w*h); found || steps >= 4*w*h {
+				t.Errorf("%s on %s torus: walled off treasure found %v, or not given up on after %d steps", s.name, mz.name, found, steps)
+			}
+		}
+	}
+}
+
+func TestFindTreasureHoard(t *testing.T) {
+	solvers := []struct {
+		name   string
+		solver func(<-chan MazeReply) <-chan int
+	}{
+		{"FindTreasure", FindTreasure},
+		{"FindTreasureDirected", FindTreasureDirected},
+		{"FindTreasureLooking", FindTreasureLooking},
+	}
+
+	for _, s := range solvers {
+		for i := 0; i < 50; i++ {
+			m := NewFullGrid(6, 6)
+			comb(m)
+			m.SetStartPoint(rand.Intn(6), rand.Intn(6))
+			for m.SetTreasure(rand.Intn(6), rand.Intn(6)) != nil {
+			}
+			ScatterTreasures(m, 3)
+			if steps, found := Solve(m, s.solver, 1000); !found || m.Remaining() != 0 {
+				t.Fatalf("%s: victory %t after %d steps, with %d treasures left", s.name, found, steps, m.Remaining())
+			}
+		}
+	}
+}
+
+func TestFindTreasureLookingRoute(t *testing.T) {
+	// the treasures are down the back of the comb, where every room
+	// but the last is a junction with a tooth going down
+	tests := []struct {
+		name      string
+		treasures []int
+		steps     int
+	}{
+		{"one treasure", []int{6}, 6},
+		{"the nearer treasure first", []int{6, 3}, 6},
+		{"looking again from a treasure", []int{1, 6}, 6},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			m := NewFullGrid(7, 3)
+			comb(m)
+			m.SetStartPoint(0, 0)
+			m.SetTreasure(tt.treasures[0], 0)
+			for _, x := range tt.treasures[1:] {
+				m.AddTreasure(x, 0)
+			}
+			if steps, found := Solve(m, FindTreasureLooking, 100); !found || steps != tt.steps {
+				t.Fatalf("%s: victory %t after %d steps, want %d", tt.name, found, steps, tt.steps)
+			}
+		}
+	}
+}
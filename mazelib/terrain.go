@@ -0,0 +1,111 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"math/rand"
+)
+
+// Terrain is the ground of a room, some of it is slower to cross
+type Terrain int
+
+// Floor is the terrain of every room that hasn't been painted
+const (
+	Floor Terrain = iota
+	Mud
+	Water
+)
+
+// TerrainCost is the cost of entering a room of each terrain
+var TerrainCost = map[Terrain]int{
+	Floor: 1,
+	Mud:   3,
+	Water: 5,
+}
+
+// terrainSymbol is how each terrain is printed by PrintMaze
+var terrainSymbol = map[Terrain]string{
+	Mud:   ":",
+	Water: "~",
+}
+
+// StairsCost is the cost of taking the stairs to another level
+const StairsCost = 2
+
+// Cost returns the cost of moving in the given direction.
+// Mazes without terrain leave the costs at 0, which is a cost of 1.
+func (s Survey) Cost(dir int) int {
+	var c int
+	switch dir {
+	case N:
+		c = s.TopCost
+	case S:
+		c = s.BottomCost
+	case E:
+		c = s.RightCost
+	case W:
+		c = s.LeftCost
+	case U, D:
+		return StairsCost
+	}
+	if c == 0 {
+		return 1
+	}
+	return c
+}
+
+// setCost sets the cost of moving in the given direction
+func (s *Survey) setCost(dir, c int) {
+	switch dir {
+	case N:
+		s.TopCost = c
+	case S:
+		s.BottomCost = c
+	case E:
+		s.RightCost = c
+	case W:
+		s.LeftCost = c
+	}
+}
+
+// Painter is implemented by mazes with terrain
+type Painter interface {
+	Width() int
+	Height() int
+	Paint(x, y int, t Terrain)
+}
+
+// PaintRegions paints n regions of mud or water on the maze. Each region
+// grows from a random room to its neighbours, walls or not, so it looks
+// like a puddle rather than following the passages.
+func PaintRegions(m Painter, n int) {
+	w, h := m.Width(), m.Height()
+
+	for i := 0; i < n; i++ {
+		t := Mud
+		if rand.Intn(3) == 0 {
+			t = Water
+		}
+		size := 1 + rand.Intn(w*h/n/2+1)
+
+		src := Coordinate{X: rand.Intn(w), Y: rand.Intn(h)}
+		seen := map[Coordinate]bool{src: true}
+		queue := []Coordinate{src}
+		for painted := 0; painted < size && len(queue) > 0; painted++ {
+			// grow from a random room at the edge of the region
+			j := rand.Intn(len(queue))
+			cur := queue[j]
+			queue[j] = queue[len(queue)-1]
+			queue = queue[:len(queue)-1]
+
+			m.Paint(cur.X, cur.Y, t)
+			for _, dir := range []int{N, S, E, W} {
+				next, ok := neighbour(cur, dir, w, h, false)
+				if ok && !seen[next] {
+					seen[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+	}
+}
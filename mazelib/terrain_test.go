@@ -0,0 +1,91 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+func TestSurveyCost(t *testing.T) {
+	s := Survey{TopCost: TerrainCost[Mud], RightCost: TerrainCost[Water]}
+	tests := []struct {
+		dir  int
+		cost int
+	}{
+		{N, 3},
+		{E, 5},
+		// unpainted rooms cost 1 to enter
+		{S, 1},
+		{W, 1},
+		{U, StairsCost},
+		{D, StairsCost},
+	}
+
+	for _, tt := range tests {
+		if got := s.Cost(tt.dir); got != tt.cost {
+			t.Errorf("Cost(%d) is %d, want %d", tt.dir, got, tt.cost)
+		}
+	}
+}
+
+func TestPaint(t *testing.T) {
+	// Icarus walks from the floor through the mud into the water
+	m := corridor()
+	m.Paint(1, 0, Mud)
+	m.Paint(2, 0, Water)
+
+	tests := []struct {
+		x, y int
+		dir  int
+		cost int
+	}{
+		{0, 0, E, 3},
+		{1, 0, W, 1},
+		{1, 0, E, 5},
+		{2, 0, W, 3},
+	}
+	for _, tt := range tests {
+		s, _ := m.Discover(tt.x, tt.y)
+		if got := s.Cost(tt.dir); got != tt.cost {
+			t.Errorf("(%d, %d): Cost(%d) is %d, want %d", tt.x, tt.y, tt.dir, got, tt.cost)
+		}
+	}
+
+	m.MoveRight()
+	m.MoveRight()
+	if m.Steps() != 2 || m.Cost() != 8 {
+		t.Errorf("%d steps costing %d, want 2 costing 8", m.Steps(), m.Cost())
+	}
+
+	// rock stays rock
+	m = NewFullGrid(2, 2)
+	m.Exclude(1, 1)
+	m.Paint(1, 1, Mud)
+	if r, _ := m.GetRoom(1, 1); r.Terrain != Floor {
+		t.Errorf("rock painted %d", r.Terrain)
+	}
+}
+
+func TestPaintRegions(t *testing.T) {
+	// each region is at most 1 + 100/4/2 rooms
+	for i := 0; i < 20; i++ {
+		m := NewFullGrid(10, 10)
+		PaintRegions(m, 4)
+
+		painted := 0
+		for y := 0; y < m.Height(); y++ {
+			for x := 0; x < m.Width(); x++ {
+				r, _ := m.GetRoom(x, y)
+				if _, ok := TerrainCost[r.Terrain]; !ok {
+					t.Fatalf("room (%d, %d) painted %d", x, y, r.Terrain)
+				}
+				if r.Terrain != Floor {
+					painted++
+				}
+			}
+		}
+		if painted == 0 || painted > 4*13 {
+			t.Fatalf("%d rooms painted by 4 regions", painted)
+		}
+	}
+}
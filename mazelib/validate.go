@@ -0,0 +1,240 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WallIssue describes a wall problem found at a room, facing a direction
+type WallIssue struct {
+	Coordinate
+	Direction int
+}
+
+// Validation is the result of checking a maze for consistency
+type Validation struct {
+	// AsymmetricWalls lists walls present on one side only.
+	// Each pair of neighbours is reported once, from the west or north room.
+	AsymmetricWalls []WallIssue
+
+	// PerimeterGaps lists rooms on the edge that are open to the outside
+	PerimeterGaps []WallIssue
+
+	// TreasureReachable is true if Icarus can walk from the start to the treasure
+	TreasureReachable bool
+
+	// Regions is the number of areas that are not connected to each other
+	Regions int
+
+	// Perfect is true if the maze has exactly one path between any two rooms
+	Perfect bool
+}
+
+// Valid is true if the maze can be served to Icarus without surprises
+func (v Validation) Valid() bool {
+	return len(v.AsymmetricWalls) == 0 && len(v.PerimeterGaps) == 0 && v.TreasureReachable
+}
+
+// ValidWithOneWays is like Valid for mazes with one way doors on purpose,
+// where walls present on one side only are not a problem
+func (v Validation) ValidWithOneWays() bool {
+	return len(v.PerimeterGaps) == 0 && v.TreasureReachable
+}
+
+// String gives a human readable summary of the validation
+func (v Validation) String() string {
+	lines := make([]string, 0, 8)
+	for _, w := range v.AsymmetricWalls {
+		lines = append(lines, fmt.Sprintf("one way wall at (%d, %d) facing %s", w.X, w.Y, DirectionName[w.Direction]))
+	}
+	for _, w := range v.PerimeterGaps {
+		lines = append(lines, fmt.Sprintf("gap in perimeter at (%d, %d) facing %s", w.X, w.Y, DirectionName[w.Direction]))
+	}
+	if !v.TreasureReachable {
+		lines = append(lines, "treasure cannot be reached from the start")
+	}
+	lines = append(lines, fmt.Sprintf("regions: %d, perfect: %t, valid: %t", v.Regions, v.Perfect, v.Valid()))
+	return strings.Join(lines, "\n")
+}
+
+// DirectionName gives a readable name for each direction
+var DirectionName = map[int]string{
+	N:  "north",
+	S:  "south",
+	E:  "east",
+	W:  "west",
+	U:  "up",
+	D:  "down",
+	NE: "northeast",
+	SE: "southeast",
+	SW: "southwest",
+	NW: "northwest",
+}
+
+// wallFacing returns if the survey has a wall in the given direction
+func wallFacing(s Survey, dir int) bool {
+	switch dir {
+	case N:
+		return s.Top
+	case S:
+		return s.Bottom
+	case E:
+		return s.Right
+	case W:
+		return s.Left
+	case U:
+		return s.Up
+	case D:
+		return s.Down
+	}
+	return true
+}
+
+// neighbour returns the room next to c in the given direction, or false
+// if that is outside of a w x h maze with a single level.
+// A torus wraps around at the edges.
+func neighbour(c Coordinate, dir, w, h int, torus bool) (Coordinate, bool) {
+	next := Coordinate{X: c.X + Delta[dir].X, Y: c.Y + Delta[dir].Y}
+	if Delta[dir].Z != 0 {
+		return next, false
+	}
+	if torus {
+		next.X = (next.X + w) % w
+		next.Y = (next.Y + h) % h
+		return next, true
+	}
+	return next, next.X >= 0 && next.Y >= 0 && next.X < w && next.Y < h
+}
+
+// Validate checks a maze for one way walls, gaps in the perimeter,
+// unreachable treasure and isolated regions.
+// A torus has no perimeter, instead its opposite edges must agree.
+// A maze without rooms is never valid.
+func Validate(m MazeI) Validation {
+	var v Validation
+	w, h := m.Width(), m.Height()
+	if w <= 0 || h <= 0 {
+		return v
+	}
+	torus := isTorus(m)
+
+	surveys := make([][]Survey, h)
+	rock := make(map[Coordinate]bool)
+	var treasure Coordinate
+	hasTreasure := false
+	for y := 0; y < h; y++ {
+		surveys[y] = make([]Survey, w)
+		for x := 0; x < w; x++ {
+			s, err := m.Discover(x, y)
+			if err != nil {
+				continue
+			}
+			surveys[y][x] = s
+			r, err := m.GetRoom(x, y)
+			if err != nil {
+				continue
+			}
+			if r.Treasure {
+				treasure = Coordinate{X: x, Y: y}
+				hasTreasure = true
+			}
+			if r.Excluded {
+				rock[Coordinate{X: x, Y: y}] = true
+			}
+		}
+	}
+
+	// a wall between neighbours must be seen from both sides
+	passages := 0
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			s := surveys[y][x]
+			if x < w-1 || torus {
+				if s.Right != surveys[y][(x+1)%w].Left {
+					v.AsymmetricWalls = append(v.AsymmetricWalls, WallIssue{Coordinate{X: x, Y: y}, E})
+				} else if !s.Right {
+					passages++
+				}
+			}
+			if y < h-1 || torus {
+				if s.Bottom != surveys[(y+1)%h][x].Top {
+					v.AsymmetricWalls = append(v.AsymmetricWalls, WallIssue{Coordinate{X: x, Y: y}, S})
+				} else if !s.Bottom {
+					passages++
+				}
+			}
+		}
+	}
+
+	// the perimeter must be closed
+	for x := 0; x < w && !torus; x++ {
+		if !surveys[0][x].Top {
+			v.PerimeterGaps = append(v.PerimeterGaps, WallIssue{Coordinate{X: x, Y: 0}, N})
+		}
+		if !surveys[h-1][x].Bottom {
+			v.PerimeterGaps = append(v.PerimeterGaps, WallIssue{Coordinate{X: x, Y: h - 1}, S})
+		}
+	}
+	for y := 0; y < h && !torus; y++ {
+		if !surveys[y][0].Left {
+			v.PerimeterGaps = append(v.PerimeterGaps, WallIssue{Coordinate{X: 0, Y: y}, W})
+		}
+		if !surveys[y][w-1].Right {
+			v.PerimeterGaps = append(v.PerimeterGaps, WallIssue{Coordinate{X: w - 1, Y: y}, E})
+		}
+	}
+
+	// regions only count passages open from both sides, rock is not a region
+	region := make(map[Coordinate]bool, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if region[Coordinate{X: x, Y: y}] || rock[Coordinate{X: x, Y: y}] {
+				continue
+			}
+			v.Regions++
+			flood(surveys, Coordinate{X: x, Y: y}, region, true, torus)
+		}
+	}
+
+	// reachability follows the server's rules, which only checks
+	// the wall of the room Icarus is standing in
+	if hasTreasure {
+		sx, sy := m.Icarus()
+		reached := make(map[Coordinate]bool, w*h)
+		flood(surveys, Coordinate{X: sx, Y: sy}, reached, false, torus)
+		v.TreasureReachable = reached[treasure]
+	}
+
+	v.Perfect = len(v.AsymmetricWalls) == 0 && v.Regions == 1 && passages == w*h-len(rock)-1
+	return v
+}
+
+// flood marks every room reachable from src. If both is true, a passage
+// must be open from both sides to be followed.
+func flood(surveys [][]Survey, src Coordinate, seen map[Coordinate]bool, both, torus bool) {
+	h := len(surveys)
+	w := len(surveys[0])
+
+	seen[src] = true
+	queue := []Coordinate{src}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, dir := range []int{N, S, E, W} {
+			if wallFacing(surveys[cur.Y][cur.X], dir) {
+				continue
+			}
+			next, ok := neighbour(cur, dir, w, h, torus)
+			if !ok || seen[next] {
+				continue
+			}
+			if both && wallFacing(surveys[next.Y][next.X], Opposite[dir]) {
+				continue
+			}
+			seen[next] = true
+			queue = append(queue, next)
+		}
+	}
+}
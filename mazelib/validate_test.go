@@ -0,0 +1,79 @@
+// By Kelvin Yong for Go Challenge 6
+
+package mazelib
+
+import (
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		maze      func() MazeI
+		valid     bool
+		perfect   bool
+		regions   int
+		oneWays   int
+		perimeter int
+	}{
+		{"corridor", func() MazeI { return corridor() }, true, true, 1, 0, 0},
+		{"loop", func() MazeI {
+			m := NewEmptyGrid(2, 2)
+			m.SetStartPoint(0, 0)
+			m.SetTreasure(1, 1)
+			return m
+		}, true, false, 1, 0, 0},
+		{"walled off treasure", func() MazeI {
+			m := corridor()
+			m.AddWall(1, 0, E)
+			return m
+		}, false, false, 2, 0, 0},
+		{"one way wall", func() MazeI {
+			m := corridor()
+			m.AddWall(1, 0, E)
+			m.OneWay(1, 0, E)
+			return m
+		}, false, false, 2, 1, 0},
+		{"gap in the perimeter", func() MazeI {
+			m := corridor()
+			m.RmWall(1, 0, N)
+			return m
+		}, false, true, 1, 0, 1},
+		{"torus", func() MazeI {
+			m := NewFullTorus(3, 1)
+			m.RmWall(0, 0, E)
+			m.RmWall(1, 0, E)
+			m.SetStartPoint(0, 0)
+			m.SetTreasure(2, 0)
+			return m
+		}, true, true, 1, 0, 0},
+		{"torus with a loop", func() MazeI {
+			m := NewEmptyTorus(3, 1)
+			m.SetStartPoint(0, 0)
+			m.SetTreasure(2, 0)
+			return m
+		}, true, false, 1, 0, 0},
+		{"no rooms", func() MazeI { return NewCompactMaze(0, 0) }, false, false, 0, 0, 0},
+		{"no rows", func() MazeI { return NewCompactMaze(3, 0) }, false, false, 0, 0, 0},
+		{"no columns", func() MazeI { return NewCompactMaze(0, 3) }, false, false, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		v := Validate(tt.maze())
+		if v.Valid() != tt.valid {
+			t.Errorf("%s: valid is %t, want %t\n%s", tt.name, v.Valid(), tt.valid, v)
+		}
+		if v.Perfect != tt.perfect {
+			t.Errorf("%s: perfect is %t, want %t", tt.name, v.Perfect, tt.perfect)
+		}
+		if v.Regions != tt.regions {
+			t.Errorf("%s: %d regions, want %d", tt.name, v.Regions, tt.regions)
+		}
+		if len(v.AsymmetricWalls) != tt.oneWays {
+			t.Errorf("%s: %d one way walls, want %d", tt.name, len(v.AsymmetricWalls), tt.oneWays)
+		}
+		if len(v.PerimeterGaps) != tt.perimeter {
+			t.Errorf("%s: %d gaps in the perimeter, want %d", tt.name, len(v.PerimeterGaps), tt.perimeter)
+		}
+	}
+}
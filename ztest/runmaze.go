@@ -1,7 +1,6 @@
 package main
 
 import (
-	"errors"
 	"fmt"
 	"math/rand"
 	"time"
@@ -9,241 +8,34 @@ import (
 	"bitbucket.org/kelvinyong/gc6/mazelib"
 )
 
-//Maze struct
-type Maze struct {
-	rooms      [][]mazelib.Room
-	start      mazelib.Coordinate
-	end        mazelib.Coordinate
-	icarus     mazelib.Coordinate
-	StepsTaken int
-}
-
-// GetRoom returns a Room struct
-func (m *Maze) GetRoom(x, y int) (*mazelib.Room, error) {
-	if x < 0 || y < 0 || x >= m.Width() || y >= m.Height() {
-		return &mazelib.Room{}, errors.New("room outside of maze boundaries")
-	}
-
-	return &m.rooms[y][x], nil
-}
-
-// Width returns width of the maze
-func (m *Maze) Width() int { return len(m.rooms[0]) }
-
-// Height returns height of the maze
-func (m *Maze) Height() int { return len(m.rooms) }
-
-// Icarus returns the finder's current position
-func (m *Maze) Icarus() (x, y int) {
-	return m.icarus.X, m.icarus.Y
-}
-
-// SetStartPoint sets the location where Icarus will awake
-func (m *Maze) SetStartPoint(x, y int) error {
-	r, err := m.GetRoom(x, y)
-
-	if err != nil {
-		return err
-	}
-
-	if r.Treasure {
-		return errors.New("can't start in the treasure")
-	}
-
-	r.Start = true
-	m.icarus = mazelib.Coordinate{x, y}
-	return nil
-}
-
-// SetTreasure sets the location of the treasure for a given maze
-func (m *Maze) SetTreasure(x, y int) error {
-	r, err := m.GetRoom(x, y)
-
-	if err != nil {
-		return err
-	}
-
-	if r.Start {
-		return errors.New("can't have the treasure at the start")
-	}
-
-	r.Treasure = true
-	m.end = mazelib.Coordinate{x, y}
-	return nil
-}
-
-// LookAround Given Icarus's current location, Discover that room
-// Will return ErrVictory if Icarus is at the treasure.
-func (m *Maze) LookAround() (mazelib.Survey, error) {
-	if m.end.X == m.icarus.X && m.end.Y == m.icarus.Y {
-		//fmt.Printf("Victory achieved in %d steps \n", m.StepsTaken)
-		if mCount > 0 {
-			ms := mazeStats[(mCount-1)/100]
-			ms.steps += m.StepsTaken
-			ms.times++
-		}
-		return mazelib.Survey{}, mazelib.ErrVictory
-	}
-
-	return m.Discover(m.icarus.X, m.icarus.Y)
-}
-
-// Discover Given two points, survey the room.
-// Will return error if two points are outside of the maze
-func (m *Maze) Discover(x, y int) (mazelib.Survey, error) {
-	r, err := m.GetRoom(x, y)
-	if err != nil {
-		return mazelib.Survey{}, err
-	}
-	return r.Walls, nil
-}
-
-// MoveLeft Moves Icarus's position left one step
-// Will not permit moving through walls or out of the maze
-func (m *Maze) MoveLeft() error {
-	s, e := m.LookAround()
-	if e != nil {
-		return e
-	}
-	if s.Left {
-		return errors.New("Can't walk through walls")
-	}
-
-	x, y := m.Icarus()
-	if _, err := m.GetRoom(x-1, y); err != nil {
-		return err
-	}
-
-	m.icarus = mazelib.Coordinate{x - 1, y}
-	m.StepsTaken++
-	return nil
-}
-
-// MoveRight Moves Icarus's position right one step
-// Will not permit moving through walls or out of the maze
-func (m *Maze) MoveRight() error {
-	s, e := m.LookAround()
-	if e != nil {
-		return e
-	}
-	if s.Right {
-		return errors.New("Can't walk through walls")
-	}
-
-	x, y := m.Icarus()
-	if _, err := m.GetRoom(x+1, y); err != nil {
-		return err
-	}
-
-	m.icarus = mazelib.Coordinate{x + 1, y}
-	m.StepsTaken++
-	return nil
-}
-
-// MoveUp Moves Icarus's position up one step
-// Will not permit moving through walls or out of the maze
-func (m *Maze) MoveUp() error {
-	s, e := m.LookAround()
-	if e != nil {
-		return e
-	}
-	if s.Top {
-		return errors.New("Can't walk through walls")
-	}
-
-	x, y := m.Icarus()
-	if _, err := m.GetRoom(x, y-1); err != nil {
-		return err
-	}
-
-	m.icarus = mazelib.Coordinate{x, y - 1}
-	m.StepsTaken++
-	return nil
-}
-
-// MoveDown Moves Icarus's position down one step
-// Will not permit moving through walls or out of the maze
-func (m *Maze) MoveDown() error {
-	s, e := m.LookAround()
-	if e != nil {
-		return e
-	}
-	if s.Bottom {
-		return errors.New("Can't walk through walls")
-	}
-
-	x, y := m.Icarus()
-	if _, err := m.GetRoom(x, y+1); err != nil {
-		return err
-	}
-
-	m.icarus = mazelib.Coordinate{x, y + 1}
-	m.StepsTaken++
-	return nil
-}
-
-// Utility method to add wall to room
-func (m *Maze) addWallAt(cx, cy, direction int) {
-	w, h := m.Width(), m.Height()
-
-	if cx >= 0 && cx < w && cy >= 0 && cy < h {
-		m.rooms[cy][cx].AddWall(direction)
-	}
-
-	nx, ny := cx+mazelib.Delta[direction].X, cy+mazelib.Delta[direction].Y
-	if nx >= 0 && nx < w && ny >= 0 && ny < h {
-		m.rooms[ny][nx].AddWall(mazelib.Opposite[direction])
-	}
-}
-
 func init() {
 	rand.Seed(time.Now().UTC().UnixNano()) // need to initialize the seed
 }
 
-func emptyMaze() *Maze {
-	z := Maze{}
-	ySize := 30
-	xSize := 30
-
-	z.rooms = make([][]mazelib.Room, ySize)
-	for y := 0; y < ySize; y++ {
-		z.rooms[y] = make([]mazelib.Room, xSize)
-		for x := 0; x < xSize; x++ {
-			z.rooms[y][x] = mazelib.Room{}
-		}
-	}
-
-	// Add walls for top and bottom boundaries
-	for x := 0; x < xSize; x++ {
-		z.rooms[0][x].AddWall(mazelib.N)
-		z.rooms[ySize-1][x].AddWall(mazelib.S)
-	}
-
-	// Add walls for left and right boundaries
-	for y := 0; y < ySize; y++ {
-		z.rooms[y][0].AddWall(mazelib.W)
-		z.rooms[y][xSize-1].AddWall(mazelib.E)
+// updateStats is the victory hook of every maze, it adds
+// the steps taken to the statistics of the current maze type
+func updateStats(m *mazelib.GridMaze) {
+	if mCount > 0 {
+		ms := mazeStats[(mCount-1)/100]
+		ms.steps += m.StepsTaken
+		ms.times++
 	}
-
-	return &z
 }
 
-func fullMaze() *Maze {
-	z := emptyMaze()
-	ySize := 30
-	xSize := 30
-
-	for y := 0; y < ySize; y++ {
-		for x := 0; x < xSize; x++ {
-			z.rooms[y][x].Walls = mazelib.Survey{true, true, true, true}
-		}
-	}
+func emptyMaze() *mazelib.GridMaze {
+	z := mazelib.NewEmptyGrid(30, 30)
+	z.Hooks.OnVictory = updateStats
+	return z
+}
 
+func fullMaze() *mazelib.GridMaze {
+	z := mazelib.NewFullGrid(30, 30)
+	z.Hooks.OnVictory = updateStats
 	return z
 }
 
 //////////////// A1. Recursive BackTracker Algo ////////////////
-func carvePassages(m *Maze, cx int, cy int) {
+func carvePassages(m *mazelib.GridMaze, cx int, cy int) {
 	directions := []int{mazelib.N, mazelib.S, mazelib.E, mazelib.W}
 	mazelib.Shuffle(directions)
 
@@ -262,8 +54,8 @@ func carvePassages(m *Maze, cx int, cy int) {
 
 var mazeName string
 
-func createMaze(mazeType int) *Maze {
-	var m *Maze
+func createMaze(mazeType int) *mazelib.GridMaze {
+	var m *mazelib.GridMaze
 	var xSize, ySize int
 
 	oldName := mazeName
@@ -284,8 +76,7 @@ func createMaze(mazeType int) *Maze {
 		for y := 0; y < ySize-1; y++ {
 			for x := 0; x < xSize; x++ {
 				if (y%2 == 0 && x != xSize-1) || (y%2 == 1 && x != 0) {
-					m.rooms[y][x].AddWall(mazelib.S)
-					m.rooms[y+1][x].AddWall(mazelib.N)
+					m.AddWall(x, y, mazelib.S)
 				}
 			}
 		}
@@ -305,11 +96,9 @@ func createMaze(mazeType int) *Maze {
 
 		for y := 0; y < ySize-1; y++ {
 			for x := 1; x < xSize-1; x++ {
-				m.rooms[y][x].AddWall(mazelib.S)
-				m.rooms[y+1][x].AddWall(mazelib.N)
+				m.AddWall(x, y, mazelib.S)
 			}
-			m.rooms[y][0].AddWall(mazelib.E)
-			m.rooms[y][1].AddWall(mazelib.W)
+			m.AddWall(0, y, mazelib.E)
 		}
 
 	case 5:
@@ -320,8 +109,7 @@ func createMaze(mazeType int) *Maze {
 
 		for y := 1; y < ySize-1; y++ {
 			for x := 0; x < xSize-1; x++ {
-				m.rooms[y][x].AddWall(mazelib.E)
-				m.rooms[y][x+1].AddWall(mazelib.W)
+				m.AddWall(x, y, mazelib.E)
 			}
 		}
 
@@ -330,8 +118,7 @@ func createMaze(mazeType int) *Maze {
 			y = ySize - 1
 		}
 		for x := 0; x < xSize-1; x++ {
-			m.rooms[y][x].AddWall(mazelib.E)
-			m.rooms[y][x+1].AddWall(mazelib.W)
+			m.AddWall(x, y, mazelib.E)
 		}
 
 	case 6:
@@ -367,8 +154,8 @@ func createMaze(mazeType int) *Maze {
 
 		for i, edge := range edges {
 			x, y, dir := edge.x, edge.y, edge.dir
-			thisCoor := mazelib.Coordinate{x, y}
-			nextCoor := mazelib.Coordinate{x + mazelib.Delta[dir].X, y + mazelib.Delta[dir].Y}
+			thisCoor := mazelib.Coordinate{X: x, Y: y}
+			nextCoor := mazelib.Coordinate{X: x + mazelib.Delta[dir].X, Y: y + mazelib.Delta[dir].Y}
 
 			thisSetID, nextSetID := -1, -1
 			for id, m := range sets {
@@ -411,8 +198,7 @@ func createMaze(mazeType int) *Maze {
 				delete(sets, nextSetID)
 			}
 			// remove the walls linking to them
-			m.rooms[thisCoor.Y][thisCoor.X].RmWall(dir)
-			m.rooms[nextCoor.Y][nextCoor.X].RmWall(mazelib.Opposite[dir])
+			m.RmWall(x, y, dir)
 		}
 
 	default:
@@ -455,7 +241,7 @@ func trialMaze(mazeType, tries int) int {
 		}
 		replies := make(chan mazelib.MazeReply)
 		steps := mazelib.FindTreasure(replies)
-		replies <- mazelib.MazeReply{s, nil}
+		replies <- mazelib.MazeReply{Survey: s}
 
 		currentCount := 0
 		for step := range steps {
@@ -480,7 +266,7 @@ func trialMaze(mazeType, tries int) int {
 					trace = false
 				}
 			}
-			replies <- mazelib.MazeReply{s, e}
+			replies <- mazelib.MazeReply{Survey: s, Err: e}
 		}
 		if trace {
 			fmt.Print("#################### Solved ####################\n\n")
@@ -504,7 +290,7 @@ var mazeStats = make([]*mStat, 0)
 
 // changes the maze type for every 100 mazes
 // base on statistics
-func makeMaze() *Maze {
+func makeMaze() *mazelib.GridMaze {
 	if mCount%100 == 0 {
 
 		switch mCount / 100 {
@@ -575,7 +361,7 @@ func main() {
 		}
 		replies := make(chan mazelib.MazeReply)
 		steps := mazelib.FindTreasure(replies)
-		replies <- mazelib.MazeReply{s, nil}
+		replies <- mazelib.MazeReply{Survey: s}
 
 		for step := range steps {
 			switch step {
@@ -598,7 +384,7 @@ func main() {
 					trace = false
 				}
 			}
-			replies <- mazelib.MazeReply{s, e}
+			replies <- mazelib.MazeReply{Survey: s, Err: e}
 		}
 		if trace {
 			fmt.Print("#################### Solved ####################\n\n")